@@ -0,0 +1,330 @@
+// Package aip160 parses list filters as described in https://google.aip.dev/160, like
+// 'age >= 30 AND name:"jess*" AND NOT archived', into gormqonvert conditions.
+//
+// Fields are resolved against a gorm schema using their Go name or column name, fields of embedded structs can be
+// used with a dotted path like 'address.city'. Values are converted to the type of the field. The supported
+// comparators are '=', '!=', '<', '<=', '>', '>=' and ':', where '=', '!=' and ':' treat '*' in strings as a wildcard
+// and ':' checks if a string contains the value. '*' is the only wildcard, so '%' and '_' match literally. A field
+// without a comparator must be a boolean.
+package aip160
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+	"github.com/survivorbat/gorm-query-convert/internal/lexer"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrUnknownField is returned if a field in the filter does not exist in the schema
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrTypeMismatch is returned if a value in the filter can't be converted to the type of its field
+	ErrTypeMismatch = errors.New("type mismatch")
+
+	// ErrMissingSchema is returned if no schema was given to resolve the fields against
+	ErrMissingSchema = errors.New("missing schema")
+)
+
+// comparatorOperators maps comparators to the operators of the plugin, for non-string fields
+var comparatorOperators = map[string]gormqonvert.Operator{
+	"=":  gormqonvert.OperatorEqual,
+	":":  gormqonvert.OperatorEqual,
+	"!=": gormqonvert.OperatorNotEqual,
+	"<":  gormqonvert.OperatorLessThan,
+	"<=": gormqonvert.OperatorLessOrEqualTo,
+	">":  gormqonvert.OperatorGreaterThan,
+	">=": gormqonvert.OperatorGreaterOrEqualTo,
+}
+
+// SyntaxError is returned if a filter could not be parsed, Position is the byte offset in the filter at which
+// the problem was found.
+type SyntaxError = lexer.SyntaxError
+
+// Parse turns a filter into conditions using the fields of the schema, they can be added to a query using
+// db.Scopes(gormqonvert.Scope(conditions...)). An empty filter results in no conditions.
+func Parse(filter string, modelSchema *schema.Schema) ([]gormqonvert.Condition, error) {
+	if modelSchema == nil {
+		return nil, ErrMissingSchema
+	}
+
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{Cursor: lexer.Cursor{Tokens: tokens}, schema: modelSchema}
+
+	if p.Peek().Kind == lexer.End {
+		return []gormqonvert.Condition{}, nil
+	}
+
+	condition, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.Peek(); next.Kind != lexer.End {
+		return nil, lexer.Errorf(next.Position, "unexpected '%s'", next.Text)
+	}
+
+	if condition.Operator == gormqonvert.OperatorAnd {
+		return condition.Conditions, nil
+	}
+
+	return []gormqonvert.Condition{condition}, nil
+}
+
+// ParseModel is like Parse, but uses the schema of the given model
+func ParseModel(db *gorm.DB, model any, filter string) ([]gormqonvert.Condition, error) {
+	statement := &gorm.Statement{DB: db}
+	if err := statement.Parse(model); err != nil {
+		return nil, err
+	}
+
+	return Parse(filter, statement.Schema)
+}
+
+type parser struct {
+	lexer.Cursor
+	schema *schema.Schema
+}
+
+// parseExpression parses sequences separated by AND
+func (p *parser) parseExpression() (gormqonvert.Condition, error) {
+	conditions := []gormqonvert.Condition{}
+
+	for {
+		sequence, err := p.parseSequence()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		conditions = append(conditions, sequence)
+
+		if !p.Peek().Is("AND") {
+			break
+		}
+
+		p.Position++
+	}
+
+	return combine(gormqonvert.And, conditions), nil
+}
+
+// parseSequence parses factors separated by whitespace, which are implicitly combined using AND
+func (p *parser) parseSequence() (gormqonvert.Condition, error) {
+	conditions := []gormqonvert.Condition{}
+
+	for {
+		factor, err := p.parseFactor()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		conditions = append(conditions, factor)
+
+		next := p.Peek()
+		if next.Kind == lexer.End || next.Kind == lexer.RightParenthesis || next.Is("AND") {
+			break
+		}
+	}
+
+	return combine(gormqonvert.And, conditions), nil
+}
+
+// parseFactor parses terms separated by OR, which takes precedence over AND
+func (p *parser) parseFactor() (gormqonvert.Condition, error) {
+	conditions := []gormqonvert.Condition{}
+
+	for {
+		term, err := p.parseTerm()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		conditions = append(conditions, term)
+
+		if !p.Peek().Is("OR") {
+			break
+		}
+
+		p.Position++
+	}
+
+	return combine(gormqonvert.Or, conditions), nil
+}
+
+// parseTerm parses an optionally negated simple expression
+func (p *parser) parseTerm() (gormqonvert.Condition, error) {
+	current := p.Peek()
+
+	switch {
+	case current.Is("NOT"):
+		p.Position++
+
+	case current.Kind == lexer.Text && len(current.Text) > 1 && current.Text[0] == '-':
+		p.Tokens[p.Position].Text = current.Text[1:]
+		p.Tokens[p.Position].Position++
+
+	default:
+		return p.parseSimple()
+	}
+
+	condition, err := p.parseSimple()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	return gormqonvert.Not(condition), nil
+}
+
+// parseSimple parses an expression between parentheses or a restriction
+func (p *parser) parseSimple() (gormqonvert.Condition, error) {
+	current := p.Next()
+
+	switch {
+	case current.Kind == lexer.LeftParenthesis:
+		condition, err := p.parseExpression()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		if err := p.Expect(lexer.RightParenthesis, "')'"); err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		return condition, nil
+
+	case current.Kind != lexer.Text || current.Is("AND") || current.Is("OR") || current.Is("NOT"):
+		return gormqonvert.Condition{}, lexer.Errorf(current.Position, "expected field")
+	}
+
+	field, err := p.field(current)
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	if p.Peek().Kind != lexer.Comparator {
+		if field.DataType != schema.Bool {
+			return gormqonvert.Condition{}, lexer.Errorf(p.Peek().Position, "expected comparator")
+		}
+
+		return gormqonvert.Condition{Column: field.DBName, Operator: gormqonvert.OperatorEqual, Value: true}, nil
+	}
+
+	comparator := p.Next()
+
+	argument := p.Next()
+	if argument.Kind != lexer.Text && argument.Kind != lexer.String {
+		return gormqonvert.Condition{}, lexer.Errorf(argument.Position, "expected value")
+	}
+
+	return restriction(current.Text, field, comparator.Text, argument)
+}
+
+// field finds the field of the token in the schema
+func (p *parser) field(current lexer.Token) (*schema.Field, error) {
+	segments := strings.Split(current.Text, ".")
+
+	for _, field := range p.schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+
+		if len(segments) == 1 && segments[0] == field.DBName {
+			return field, nil
+		}
+
+		if matchesPath(field.BindNames, segments) {
+			return field, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w '%s' at position %d", ErrUnknownField, current.Text, current.Position)
+}
+
+// matchesPath returns true if the segments refer to the names, ignoring case and underscores
+func matchesPath(names []string, segments []string) bool {
+	if len(names) != len(segments) {
+		return false
+	}
+
+	for index, name := range names {
+		if !strings.EqualFold(strings.ReplaceAll(segments[index], "_", ""), name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// restriction creates the condition of a comparison on a field
+func restriction(path string, field *schema.Field, comparator string, argument lexer.Token) (gormqonvert.Condition, error) {
+	condition := gormqonvert.Condition{Column: field.DBName}
+
+	if field.DataType == schema.String && (comparator == "=" || comparator == "!=" || comparator == ":") {
+		condition.Value = argument.Text
+
+		switch {
+		case strings.Contains(argument.Text, "*"):
+			condition.Value = gormqonvert.WildcardPattern(argument.Text, "*")
+			condition.Escaped = true
+			condition.Operator = gormqonvert.OperatorLike
+			if comparator == "!=" {
+				condition.Operator = gormqonvert.OperatorNotLike
+			}
+		case comparator == ":":
+			condition.Operator = gormqonvert.OperatorContains
+		case comparator == "!=":
+			condition.Operator = gormqonvert.OperatorNotEqual
+		default:
+			condition.Operator = gormqonvert.OperatorEqual
+		}
+
+		return condition, nil
+	}
+
+	value, err := convert(field.DataType, argument.Text)
+	if err != nil {
+		return gormqonvert.Condition{}, fmt.Errorf("%w: '%s' is not a valid %s for '%s' at position %d", ErrTypeMismatch, argument.Text, field.DataType, path, argument.Position)
+	}
+
+	condition.Value = value
+	condition.Operator = comparatorOperators[comparator]
+
+	return condition, nil
+}
+
+// convert turns the text into a value of the data type, unknown types are returned as text
+func convert(dataType schema.DataType, text string) (any, error) {
+	switch dataType {
+	case schema.Bool:
+		return strconv.ParseBool(text)
+	case schema.Int:
+		return strconv.ParseInt(text, 10, 64)
+	case schema.Uint:
+		return strconv.ParseUint(text, 10, 64)
+	case schema.Float:
+		return strconv.ParseFloat(text, 64)
+	case schema.Time:
+		return time.Parse(time.RFC3339, text)
+	}
+
+	return text, nil
+}
+
+// combine returns the only condition, or all of them combined
+func combine(combinator func(...gormqonvert.Condition) gormqonvert.Condition, conditions []gormqonvert.Condition) gormqonvert.Condition {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return combinator(conditions...)
+}
@@ -0,0 +1,249 @@
+package aip160
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+)
+
+type Address struct {
+	City string
+}
+
+type ObjectA struct {
+	Name      string
+	Age       int
+	Score     float64
+	Archived  bool
+	CreatedAt time.Time
+	Address   Address `gorm:"embedded"`
+}
+
+func TestParseModel_ReturnsExpectedConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   string
+		expected []gormqonvert.Condition
+	}{
+		"nothing": {
+			filter:   "  ",
+			expected: []gormqonvert.Condition{},
+		},
+		"comparisons": {
+			filter: `age >= 30 AND score < 2.5 AND created_at > "2024-01-01T00:00:00Z"`,
+			expected: []gormqonvert.Condition{
+				{Column: "age", Operator: gormqonvert.OperatorGreaterOrEqualTo, Value: int64(30)},
+				{Column: "score", Operator: gormqonvert.OperatorLessThan, Value: 2.5},
+				{Column: "created_at", Operator: gormqonvert.OperatorGreaterThan, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		"strings": {
+			filter: `Name = "jessica" name != amy name:ss name:"jess*" name!="*a"`,
+			expected: []gormqonvert.Condition{
+				{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "jessica"},
+				{Column: "name", Operator: gormqonvert.OperatorNotEqual, Value: "amy"},
+				{Column: "name", Operator: gormqonvert.OperatorContains, Value: "ss"},
+				{Column: "name", Operator: gormqonvert.OperatorLike, Value: "jess%", Escaped: true},
+				{Column: "name", Operator: gormqonvert.OperatorNotLike, Value: "%a", Escaped: true},
+			},
+		},
+		"wildcards of like are escaped": {
+			filter: `name = "a_b*" name != "100%*"`,
+			expected: []gormqonvert.Condition{
+				{Column: "name", Operator: gormqonvert.OperatorLike, Value: "a!_b%", Escaped: true},
+				{Column: "name", Operator: gormqonvert.OperatorNotLike, Value: "100!%%", Escaped: true},
+			},
+		},
+		"booleans": {
+			filter: `NOT archived AND -archived AND archived = false`,
+			expected: []gormqonvert.Condition{
+				gormqonvert.Not(gormqonvert.Condition{Column: "archived", Operator: gormqonvert.OperatorEqual, Value: true}),
+				gormqonvert.Not(gormqonvert.Condition{Column: "archived", Operator: gormqonvert.OperatorEqual, Value: true}),
+				{Column: "archived", Operator: gormqonvert.OperatorEqual, Value: false},
+			},
+		},
+		"embedded field": {
+			filter: `address.city = Utrecht`,
+			expected: []gormqonvert.Condition{
+				{Column: "city", Operator: gormqonvert.OperatorEqual, Value: "Utrecht"},
+			},
+		},
+		"or takes precedence": {
+			filter: `age < 30 OR age > 40 AND name = amy`,
+			expected: []gormqonvert.Condition{
+				gormqonvert.Or(
+					gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorLessThan, Value: int64(30)},
+					gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterThan, Value: int64(40)},
+				),
+				{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "amy"},
+			},
+		},
+		"parentheses": {
+			filter: `(age < 30 AND name = amy) OR NOT (age > 40)`,
+			expected: []gormqonvert.Condition{
+				gormqonvert.Or(
+					gormqonvert.And(
+						gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorLessThan, Value: int64(30)},
+						gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "amy"},
+					),
+					gormqonvert.Not(gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterThan, Value: int64(40)}),
+				),
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			result, err := ParseModel(db, &ObjectA{}, testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParseModel_ReturnsExpectedErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter        string
+		expectedError error
+		expected      string
+	}{
+		"unknown field": {
+			filter:        `age > 3 AND password = "secret"`,
+			expectedError: ErrUnknownField,
+			expected:      "unknown field 'password' at position 12",
+		},
+		"type mismatch": {
+			filter:        `age > thirty`,
+			expectedError: ErrTypeMismatch,
+			expected:      "type mismatch: 'thirty' is not a valid int for 'age' at position 6",
+		},
+		"invalid time": {
+			filter:        `created_at > "yesterday"`,
+			expectedError: ErrTypeMismatch,
+			expected:      "type mismatch: 'yesterday' is not a valid time for 'created_at' at position 13",
+		},
+		"missing comparator": {
+			filter:   `age`,
+			expected: "expected comparator at position 3",
+		},
+		"missing value": {
+			filter:   `age >`,
+			expected: "expected value at position 5",
+		},
+		"missing field": {
+			filter:   `age > 3 AND`,
+			expected: "expected field at position 11",
+		},
+		"unterminated string": {
+			filter:   `name = "jess`,
+			expected: "unterminated string at position 7",
+		},
+		"unclosed parentheses": {
+			filter:   `(age > 3`,
+			expected: "expected ')' at position 8",
+		},
+		"unexpected parentheses": {
+			filter:   `age > 3)`,
+			expected: "unexpected ')' at position 7",
+		},
+		"unexpected character": {
+			filter:   `age ! 3`,
+			expected: "unexpected '!' at position 4",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			result, err := ParseModel(db, &ObjectA{}, testData.filter)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.EqualError(t, err, testData.expected)
+			if testData.expectedError != nil {
+				assert.ErrorIs(t, err, testData.expectedError)
+			}
+		})
+	}
+}
+
+func TestParseModel_FiltersQuery(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectA{})
+
+	existing := []ObjectA{
+		{Name: "jessica", Age: 31},
+		{Name: "jess", Age: 32, Archived: true},
+		{Name: "amy", Age: 30},
+		{Name: "jessie", Age: 29},
+	}
+	if err := db.CreateInBatches(existing, 10).Error; err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	conditions, err := ParseModel(db, &ObjectA{}, `age >= 30 AND name:"jess*" AND NOT archived`)
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectA
+	err = db.Scopes(gormqonvert.Scope(conditions...)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, actual, 1) {
+		assert.Equal(t, "jessica", actual[0].Name)
+	}
+}
+
+func TestParse_ReturnsErrorWithoutSchema(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := Parse("age >= 30", nil)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrMissingSchema)
+}
+
+func TestParseModel_FiltersQueryWithLiteralWildcards(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectA{})
+	_ = db.Create([]ObjectA{{Name: "axb"}, {Name: "a_b"}}).Error
+
+	conditions, err := ParseModel(db, &ObjectA{}, `name = "a_b*"`)
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectA
+	err = db.Scopes(gormqonvert.Scope(conditions...)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, actual, 1) {
+		assert.Equal(t, "a_b", actual[0].Name)
+	}
+}
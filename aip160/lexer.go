@@ -0,0 +1,81 @@
+package aip160
+
+import (
+	"strings"
+
+	"github.com/survivorbat/gorm-query-convert/internal/lexer"
+)
+
+// comparators are ordered so that longer comparators are matched first
+var comparators = []string{"<=", ">=", "!=", "<", ">", "=", ":"}
+
+// specialCharacters end a text token
+const specialCharacters = "()<>=!:\" \t\r\n"
+
+// tokenize splits the filter into tokens, the last token is always an end token
+func tokenize(filter string) ([]lexer.Token, error) {
+	return lexer.Tokenize(filter, scan)
+}
+
+// scan reads the string, comparator or text at the position
+func scan(filter string, position int) (lexer.Token, int, error) {
+	character := filter[position]
+
+	switch {
+	case character == '"':
+		text, end, ok := readString(filter, position)
+		if !ok {
+			return lexer.Token{}, 0, lexer.Errorf(position, "unterminated string")
+		}
+
+		return lexer.Token{Kind: lexer.String, Text: text, Position: position}, end, nil
+
+	case strings.IndexByte(specialCharacters, character) >= 0:
+		comparator := readComparator(filter[position:])
+		if comparator == "" {
+			return lexer.Token{}, 0, lexer.Errorf(position, "unexpected '%c'", character)
+		}
+
+		return lexer.Token{Kind: lexer.Comparator, Text: comparator, Position: position}, position + len(comparator), nil
+	}
+
+	end := position
+	for end < len(filter) && strings.IndexByte(specialCharacters, filter[end]) < 0 {
+		end++
+	}
+
+	return lexer.Token{Kind: lexer.Text, Text: filter[position:end], Position: position}, end, nil
+}
+
+// readString reads a double-quoted string starting at the given position, backslashes escape the next character.
+// It returns the position after the closing quote.
+func readString(filter string, position int) (string, int, bool) {
+	var result strings.Builder
+
+	for position++; position < len(filter); position++ {
+		switch filter[position] {
+		case '"':
+			return result.String(), position + 1, true
+		case '\\':
+			position++
+			if position == len(filter) {
+				return "", 0, false
+			}
+		}
+
+		result.WriteByte(filter[position])
+	}
+
+	return "", 0, false
+}
+
+// readComparator returns the comparator at the start of the input, or an empty string if there is none
+func readComparator(input string) string {
+	for _, comparator := range comparators {
+		if strings.HasPrefix(input, comparator) {
+			return comparator
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,37 @@
+package gormqonvert
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// alias returns the column of an alias, ok is false if the name is not an alias
+func (d *gormQonvert) alias(db *gorm.DB, name string) (string, bool) {
+	if column, ok := d.aliases[name]; ok {
+		return column, true
+	}
+
+	if !d.jsonTagAliases || db.Statement.Schema == nil {
+		return "", false
+	}
+
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName == "" || field.DBName == name {
+			continue
+		}
+
+		// Fields that are hidden from JSON can't be aliased, like encoding/json a name of '-' requires 'json:"-,"'
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if jsonName != "" && jsonName == name {
+			return field.DBName, true
+		}
+	}
+
+	return "", false
+}
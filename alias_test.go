@@ -0,0 +1,100 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGormQonvert_Aliases_ReplacesColumns(t *testing.T) {
+	t.Parallel()
+
+	type ObjectF struct {
+		Name      string `json:"fullName"`
+		CreatedAt string `json:"createdAt,omitempty"`
+		Secret    string `json:"-"`
+	}
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected string
+	}{
+		"unknown alias": {
+			options:  []Option{WithAliases(map[string]string{"fullName": "name"})},
+			filter:   map[string]any{"nickName": "jessica"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`nickName` = \"jessica\"",
+		},
+		"plain value": {
+			options:  []Option{WithAliases(map[string]string{"fullName": "name"})},
+			filter:   map[string]any{"fullName": "jessica"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`name` = \"jessica\"",
+		},
+		"prefixed value": {
+			options:  []Option{WithAliases(map[string]string{"fullName": "name"})},
+			filter:   map[string]any{"fullName": "!=jessica"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`name` != \"jessica\"",
+		},
+		"multiple values": {
+			options:  []Option{WithAliases(map[string]string{"fullName": "name"})},
+			filter:   map[string]any{"fullName": []string{"jessica", "!=amy"}},
+			expected: "SELECT * FROM `object_fs` WHERE (`object_fs`.`name` = \"jessica\" OR `object_fs`.`name` != \"amy\")",
+		},
+		"column of other table": {
+			options:  []Option{WithAliases(map[string]string{"customerName": "customer.name"})},
+			filter:   map[string]any{"customerName": ">=j"},
+			expected: "SELECT * FROM `object_fs` WHERE `customer`.`name` >= \"j\"",
+		},
+		"bracket key": {
+			options:  []Option{BracketKeys(), WithAliases(map[string]string{"fullName": "name"})},
+			filter:   map[string]any{"fullName[ne]": "jessica"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`name` != \"jessica\"",
+		},
+		"mongo document": {
+			options:  []Option{MongoOperators(), WithAliases(map[string]string{"fullName": "name"})},
+			filter:   map[string]any{"$or": []any{map[string]any{"fullName": "jessica"}, map[string]any{"fullName": "amy"}}},
+			expected: "SELECT * FROM `object_fs` WHERE (`object_fs`.`name` = \"jessica\" OR `object_fs`.`name` = \"amy\")",
+		},
+		"json tag": {
+			options:  []Option{JSONTagAliases()},
+			filter:   map[string]any{"createdAt": "<2024"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`created_at` < \"2024\"",
+		},
+		"hidden json fields are not aliases": {
+			options:  []Option{JSONTagAliases()},
+			filter:   map[string]any{"-": "!=jessica"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`-` != \"jessica\"",
+		},
+		"json tags are ignored without option": {
+			filter:   map[string]any{"createdAt": "<2024"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`createdAt` < \"2024\"",
+		},
+		"configured alias takes precedence over json tag": {
+			options:  []Option{JSONTagAliases(), WithAliases(map[string]string{"fullName": "created_at"})},
+			filter:   map[string]any{"fullName": "jessica"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`created_at` = \"jessica\"",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{LessThanPrefix: "<", GreaterOrEqualToPrefix: ">=", NotEqualToPrefix: "!="}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, testData.options...))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(&[]ObjectF{})
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bracketOperators are the operators that can be used in keys like 'age[gte]'
+var bracketOperators = map[string]Operator{
+	"eq":    OperatorEqual,
+	"ne":    OperatorNotEqual,
+	"gt":    OperatorGreaterThan,
+	"gte":   OperatorGreaterOrEqualTo,
+	"lt":    OperatorLessThan,
+	"lte":   OperatorLessOrEqualTo,
+	"like":  OperatorLike,
+	"nlike": OperatorNotLike,
+	"in":    OperatorIn,
+	"nin":   OperatorNotIn,
+}
+
+// ParseBrackets turns a filter map with keys like 'age[gte]' or 'name[like]' into conditions, as an alternative
+// to prefixed values. Values are used as-is, keys without brackets are equal-checks and values of 'in' and 'nin'
+// are split on commas. Lists of values of other operators become an Or-condition.
+func ParseBrackets(filter map[string]any) ([]Condition, error) {
+	keys := sortedKeys(filter)
+
+	result := make([]Condition, 0, len(keys))
+
+	for _, key := range keys {
+		values, ok := filterValues(filter[key])
+		if !ok {
+			continue
+		}
+
+		column, operatorName, ok := splitBracketKey(key)
+		if !ok {
+			condition, _ := keyCondition(Condition{Column: key, Operator: OperatorEqual}, values)
+			result = append(result, condition)
+			continue
+		}
+
+		operator, ok := bracketOperators[operatorName]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator '%s' in key '%s'", operatorName, key)
+		}
+
+		condition, _ := keyCondition(Condition{Column: column, Operator: operator}, values)
+		result = append(result, condition)
+	}
+
+	return result, nil
+}
+
+// splitBracketKey splits a key like 'age[gte]' into its column and operator, ok is false if the key
+// has no brackets
+func splitBracketKey(key string) (string, string, bool) {
+	if !strings.HasSuffix(key, "]") {
+		return key, "", false
+	}
+
+	start := strings.LastIndex(key, "[")
+	if start <= 0 {
+		return key, "", false
+	}
+
+	return key[:start], key[start+1 : len(key)-1], true
+}
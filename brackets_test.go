@@ -0,0 +1,89 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBrackets_ReturnsExpectedConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   map[string]any
+		expected []Condition
+	}{
+		"nothing": {
+			filter:   map[string]any{},
+			expected: []Condition{},
+		},
+		"keys without brackets": {
+			filter: map[string]any{"name": ">jessica", "age": []int{30, 31}},
+			expected: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorEqual, Value: 30},
+					Condition{Column: "age", Operator: OperatorEqual, Value: 31},
+				),
+				{Column: "name", Operator: OperatorEqual, Value: ">jessica"},
+			},
+		},
+		"comparisons": {
+			filter: map[string]any{"age[gte]": "30", "age[lt]": 40, "name[nlike]": "%a%"},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"},
+				{Column: "age", Operator: OperatorLessThan, Value: 40},
+				{Column: "name", Operator: OperatorNotLike, Value: "%a%"},
+			},
+		},
+		"in with commas": {
+			filter: map[string]any{"name[in]": "amy,boris"},
+			expected: []Condition{
+				{Column: "name", Operator: OperatorIn, Value: []any{"amy", "boris"}},
+			},
+		},
+		"not in with list": {
+			filter: map[string]any{"age[nin]": []any{30, "31,32"}},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorNotIn, Value: []any{30, "31", "32"}},
+			},
+		},
+		"multiple values": {
+			filter: map[string]any{"name[like]": []string{"%a%", "%o%"}},
+			expected: []Condition{
+				Or(
+					Condition{Column: "name", Operator: OperatorLike, Value: "%a%"},
+					Condition{Column: "name", Operator: OperatorLike, Value: "%o%"},
+				),
+			},
+		},
+		"nested brackets": {
+			filter: map[string]any{"tags[0][eq]": "a"},
+			expected: []Condition{
+				{Column: "tags[0]", Operator: OperatorEqual, Value: "a"},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := ParseBrackets(testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParseBrackets_ReturnsErrorOnUnknownOperator(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := ParseBrackets(map[string]any{"age[between]": "1,2"})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "unknown operator 'between' in key 'age[between]'")
+}
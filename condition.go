@@ -0,0 +1,401 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Operator is a comparison that a Condition applies to a column
+type Operator string
+
+const (
+	OperatorEqual            Operator = "="
+	OperatorNotEqual         Operator = "!="
+	OperatorGreaterThan      Operator = ">"
+	OperatorGreaterOrEqualTo Operator = ">="
+	OperatorLessThan         Operator = "<"
+	OperatorLessOrEqualTo    Operator = "<="
+	OperatorLike             Operator = "LIKE"
+	OperatorNotLike          Operator = "NOT LIKE"
+
+	// OperatorIn and OperatorNotIn expect a list as their Value
+	OperatorIn    Operator = "IN"
+	OperatorNotIn Operator = "NOT IN"
+
+	// OperatorContains, OperatorStartsWith and OperatorEndsWith are LIKE-checks where wildcards in the Value are
+	// escaped
+	OperatorContains   Operator = "CONTAINS"
+	OperatorStartsWith Operator = "STARTS WITH"
+	OperatorEndsWith   Operator = "ENDS WITH"
+
+	// OperatorBetween expects a list of 2 values as its Value
+	OperatorBetween Operator = "BETWEEN"
+
+	// OperatorIsNull expects a bool as its Value, false checks for IS NOT NULL instead
+	OperatorIsNull Operator = "IS NULL"
+
+	// OperatorRegex matches a regular expression, using the syntax of the database
+	OperatorRegex Operator = "REGEXP"
+
+	// OperatorOr matches if any of the Conditions of a Condition match
+	OperatorOr Operator = "OR"
+
+	// OperatorAnd matches if all of the Conditions of a Condition match
+	OperatorAnd Operator = "AND"
+
+	// OperatorNot matches if none of the Conditions of a Condition match
+	OperatorNot Operator = "NOT"
+)
+
+// Condition is a single filter on a column, like the ones the plugin creates from prefixed values. A Condition
+// with OperatorOr, OperatorAnd or OperatorNot is a group instead, its Column and Value are ignored in favour of
+// Conditions.
+type Condition struct {
+	Column   string
+	Operator Operator
+	Value    any
+
+	// IgnoreCase compares the lowercase column to the lowercase value
+	IgnoreCase bool
+
+	// Escaped means that the Value of OperatorLike or OperatorNotLike is a pattern created by WildcardPattern, in
+	// which wildcards that should match literally are escaped
+	Escaped bool
+
+	Conditions []Condition
+}
+
+// Or groups conditions together, the result matches if any of them match
+func Or(conditions ...Condition) Condition {
+	return Condition{Operator: OperatorOr, Conditions: conditions}
+}
+
+// And groups conditions together, the result matches if all of them match
+func And(conditions ...Condition) Condition {
+	return Condition{Operator: OperatorAnd, Conditions: conditions}
+}
+
+// Not negates conditions, the result matches if none of them match
+func Not(conditions ...Condition) Condition {
+	return Condition{Operator: OperatorNot, Conditions: conditions}
+}
+
+// renameColumns returns a copy of the condition where the columns of it and its nested conditions are renamed
+func (c Condition) renameColumns(rename func(string) string) Condition {
+	if c.Conditions == nil {
+		c.Column = rename(c.Column)
+		return c
+	}
+
+	conditions := make([]Condition, len(c.Conditions))
+	for index, condition := range c.Conditions {
+		conditions[index] = condition.renameColumns(rename)
+	}
+
+	c.Conditions = conditions
+
+	return c
+}
+
+// Scope adds the conditions to a query as a single WHERE-clause, use it with db.Scopes(). The conditions are not
+// converted by the plugin, so its limits, strict mode, aliases and hooks don't apply to them.
+func Scope(conditions ...Condition) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(conditions) == 0 {
+			return db
+		}
+
+		expression := And(conditions...).expression(func(condition Condition) clause.Expression {
+			return condition.comparison(db, tableColumn(clause.CurrentTable, condition.Column))
+		})
+
+		return db.Clauses(clause.Where{Exprs: []clause.Expression{expression}})
+	}
+}
+
+// expression turns the condition into a gorm expression, conditions that aren't groups are turned into expressions
+// using comparison
+func (c Condition) expression(comparison func(Condition) clause.Expression) clause.Expression {
+	if c.Operator == OperatorNot {
+		return clause.Expr{SQL: "NOT (?)", Vars: []any{Or(c.Conditions...).expression(comparison)}}
+	}
+
+	if c.Operator == OperatorOr || c.Operator == OperatorAnd {
+		// Without conditions, all records match an And-condition and none match an Or-condition
+		if len(c.Conditions) == 0 {
+			if c.Operator == OperatorAnd {
+				return clause.Expr{SQL: "1 = 1"}
+			}
+
+			return clause.Expr{SQL: "1 = 0"}
+		}
+
+		expressions := make([]clause.Expression, len(c.Conditions))
+		for index, condition := range c.Conditions {
+			expressions[index] = condition.expression(comparison)
+		}
+
+		// A single OR-condition is joined to whatever precedes it by gorm, so we unwrap it
+		if len(expressions) == 1 {
+			return expressions[0]
+		}
+
+		if c.Operator == OperatorAnd {
+			return clause.And(expressions...)
+		}
+
+		return clause.Or(expressions...)
+	}
+
+	return comparison(c)
+}
+
+// comparison turns a condition that isn't a group into a gorm expression that compares the column to the value,
+// the column is usually a clause.Column but can be any expression
+func (c Condition) comparison(db *gorm.DB, column any) clause.Expression {
+	left, right := "?", "?"
+	if c.IgnoreCase {
+		left, right = "LOWER(?)", "LOWER(?)"
+	}
+
+	switch c.Operator {
+	case OperatorContains:
+		return likeExpression(left, right, OperatorLike, column, "%"+escapeLike(fmt.Sprint(c.Value))+"%")
+	case OperatorStartsWith:
+		return likeExpression(left, right, OperatorLike, column, escapeLike(fmt.Sprint(c.Value))+"%")
+	case OperatorEndsWith:
+		return likeExpression(left, right, OperatorLike, column, "%"+escapeLike(fmt.Sprint(c.Value)))
+	case OperatorLike, OperatorNotLike:
+		if c.Escaped {
+			return likeExpression(left, right, c.Operator, column, c.Value)
+		}
+	case OperatorBetween:
+		values := toList(c.Value)
+
+		// A range without exactly two bounds can't match anything
+		if len(values) != 2 {
+			return clause.Expr{SQL: "1 = 0"}
+		}
+
+		sql := fmt.Sprintf("%s BETWEEN %s AND %s", left, right, right)
+
+		return clause.Expr{SQL: sql, Vars: []any{column, values[0], values[1]}}
+	case OperatorRegex:
+		operator := string(OperatorRegex)
+		if db.Dialector.Name() == "postgres" {
+			operator = "~"
+		}
+
+		return clause.Expr{SQL: fmt.Sprintf("%s %s ?", left, operator), Vars: []any{column, c.Value}}
+	case OperatorIsNull:
+		if isNull, _ := c.Value.(bool); !isNull {
+			return clause.Expr{SQL: "? IS NOT NULL", Vars: []any{column}}
+		}
+
+		return clause.Expr{SQL: "? IS NULL", Vars: []any{column}}
+	}
+
+	return clause.Expr{SQL: fmt.Sprintf("%s %s %s", left, c.Operator, right), Vars: []any{column, c.Value}}
+}
+
+// tableColumn creates a column of the table, unless the name is prefixed with another table like 'customer.name'
+func tableColumn(table string, name string) clause.Column {
+	if prefix, column, ok := strings.Cut(name, "."); ok {
+		return clause.Column{Table: prefix, Name: column}
+	}
+
+	return clause.Column{Table: table, Name: name}
+}
+
+// keyCondition creates a condition for operators that are found in keys instead of values, the column, operator and
+// case sensitivity are taken from the template. Values of IN-operators are combined into one list, other operators
+// get an Or-condition if there are multiple values. The result is not ok if the values don't fit the operator.
+func keyCondition(template Condition, values []any) (Condition, bool) {
+	switch template.Operator {
+	case OperatorIn, OperatorNotIn, OperatorBetween:
+		var list []any
+		for _, value := range values {
+			stringValue, ok := value.(string)
+			if !ok {
+				list = append(list, value)
+				continue
+			}
+
+			for _, part := range strings.Split(stringValue, ",") {
+				list = append(list, part)
+			}
+		}
+
+		if template.Operator == OperatorBetween && len(list) != 2 {
+			return Condition{}, false
+		}
+
+		template.Value = list
+
+		return template, true
+	case OperatorIsNull:
+		if len(values) != 1 {
+			return Condition{}, false
+		}
+
+		isNull, ok := values[0].(bool)
+		if stringValue, isString := values[0].(string); isString {
+			var err error
+			isNull, err = strconv.ParseBool(stringValue)
+			ok = err == nil
+		}
+
+		template.Value = isNull
+
+		return template, ok
+	}
+
+	if len(values) == 1 {
+		template.Value = values[0]
+		return template, true
+	}
+
+	alternatives := make([]Condition, len(values))
+	for index, value := range values {
+		alternatives[index] = template
+		alternatives[index].Value = value
+	}
+
+	return Or(alternatives...), true
+}
+
+// filterValues returns the value as a list like toList, ok is false if the list is empty since that can't be
+// expressed as a condition
+func filterValues(value any) ([]any, bool) {
+	values := toList(value)
+
+	return values, len(values) > 0
+}
+
+// toList returns the elements of a slice or array, other values are returned as a list with one element
+func toList(value any) []any {
+	if !isSlice(value) {
+		return []any{value}
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	result := make([]any, reflectValue.Len())
+	for index := range result {
+		result[index] = reflectValue.Index(index).Interface()
+	}
+
+	return result
+}
+
+// isSlice returns true if the value is a slice or an array
+func isSlice(value any) bool {
+	kind := reflect.ValueOf(value).Kind()
+
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// likeEscapeCharacter is used to escape wildcards, it's not a backslash because not every database agrees on
+// how those should be written in a string literal
+const likeEscapeCharacter = "!"
+
+// likeReplacer escapes the wildcards of LIKE-patterns
+var likeReplacer = strings.NewReplacer(
+	likeEscapeCharacter, likeEscapeCharacter+likeEscapeCharacter,
+	"%", likeEscapeCharacter+"%",
+	"_", likeEscapeCharacter+"_",
+)
+
+// escapeLike makes sure that the value matches literally in a LIKE-pattern
+func escapeLike(value string) string {
+	return likeReplacer.Replace(value)
+}
+
+// WildcardPattern turns a value in which the wildcard matches any text, like '*' in 'jes*', into a LIKE-pattern
+// for a Condition with Escaped set. Other characters match literally, including the wildcards of LIKE.
+func WildcardPattern(value string, wildcard string) string {
+	parts := strings.Split(value, wildcard)
+	for index, part := range parts {
+		parts[index] = escapeLike(part)
+	}
+
+	return strings.Join(parts, "%")
+}
+
+// likeExpression creates a LIKE or NOT LIKE-expression with a pattern that was escaped using escapeLike
+func likeExpression(left string, right string, operator Operator, column any, pattern any) clause.Expression {
+	sql := fmt.Sprintf("%s %s %s ESCAPE '%s'", left, operator, right, likeEscapeCharacter)
+
+	return clause.Expr{SQL: sql, Vars: []any{column, pattern}}
+}
+
+// prefixedOperator couples a configured prefix to the operator it represents
+type prefixedOperator struct {
+	prefix   string
+	operator Operator
+}
+
+// prefixes returns all configured prefixes in the order in which they should be matched, longer prefixes like '>='
+// have to be checked before their shorter counterparts
+func (c CharacterConfig) prefixes() []prefixedOperator {
+	all := []prefixedOperator{
+		{prefix: c.GreaterOrEqualToPrefix, operator: OperatorGreaterOrEqualTo},
+		{prefix: c.GreaterThanPrefix, operator: OperatorGreaterThan},
+		{prefix: c.LessOrEqualToPrefix, operator: OperatorLessOrEqualTo},
+		{prefix: c.LessThanPrefix, operator: OperatorLessThan},
+		{prefix: c.NotEqualToPrefix, operator: OperatorNotEqual},
+		{prefix: c.LikePrefix, operator: OperatorLike},
+		{prefix: c.NotLikePrefix, operator: OperatorNotLike},
+		{prefix: c.EqualToPrefix, operator: OperatorEqual},
+	}
+
+	result := make([]prefixedOperator, 0, len(all))
+	for _, prefixed := range all {
+		if prefixed.prefix != "" {
+			result = append(result, prefixed)
+		}
+	}
+
+	return result
+}
+
+// parse finds the operator of a prefixed value and returns the value without its prefix, ok is false if
+// the value has no known prefix
+func (c CharacterConfig) parse(value string) (Operator, string, bool) {
+	for _, prefixed := range c.prefixes() {
+		if strings.HasPrefix(value, prefixed.prefix) {
+			return prefixed.operator, value[len(prefixed.prefix):], true
+		}
+	}
+
+	return "", value, false
+}
+
+// prefix returns the configured prefix of an operator, ok is false if it was not configured
+func (c CharacterConfig) prefix(operator Operator) (string, bool) {
+	for _, prefixed := range c.prefixes() {
+		if prefixed.operator == operator {
+			return prefixed.prefix, true
+		}
+	}
+
+	return "", false
+}
+
+// sortedKeys returns the keys of the map in alphabetical order, so conditions are created in a predictable order
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,61 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestScope_AddsExpectedWhereClause(t *testing.T) {
+	t.Parallel()
+
+	type ObjectF struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		conditions []Condition
+		expected   string
+	}{
+		"nothing": {
+			expected: "SELECT * FROM `object_fs` WHERE name = \"amy\"",
+		},
+		"single condition": {
+			conditions: []Condition{
+				{Column: "age", Operator: OperatorGreaterThan, Value: 30},
+			},
+			expected: "SELECT * FROM `object_fs` WHERE name = \"amy\" AND `object_fs`.`age` > 30",
+		},
+		"groups": {
+			conditions: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorBetween, Value: []int{30, 40}},
+					Condition{Column: "name", Operator: OperatorContains, Value: "5%", IgnoreCase: true},
+				),
+				{Column: "age", Operator: OperatorIsNull, Value: false},
+			},
+			expected: "SELECT * FROM `object_fs` WHERE name = \"amy\" AND (((`object_fs`.`age` BETWEEN 30 AND 40) OR " +
+				"LOWER(`object_fs`.`name`) LIKE LOWER(\"%5!%%\") ESCAPE '!') AND `object_fs`.`age` IS NOT NULL)",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where("name = ?", "amy").Scopes(Scope(testData.conditions...)).Find(&[]ObjectF{})
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
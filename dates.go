@@ -0,0 +1,327 @@
+package gormqonvert
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// defaultDateLayouts are the absolute dates that can be used in values of time fields if no layouts were given
+var defaultDateLayouts = newDateLayouts([]string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+	"2006-01",
+	"2006",
+})
+
+// dateLayout is a layout of absolute dates, with the length of the period they describe. Periods without a length
+// are instants.
+type dateLayout struct {
+	layout string
+	years  int
+	months int
+	days   int
+}
+
+// newDateLayouts finds the length of the periods described by the layouts, by checking which parts of a time
+// survive formatting and parsing it. A layout without a time of day describes a day, one without a day a month, etc.
+func newDateLayouts(layouts []string) []dateLayout {
+	reference := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	result := make([]dateLayout, len(layouts))
+	for index, layout := range layouts {
+		result[index] = dateLayout{layout: layout}
+
+		parsed, err := time.Parse(layout, reference.Format(layout))
+
+		switch {
+		case err != nil || parsed.Hour()+parsed.Minute()+parsed.Second() != 0:
+			continue
+		case parsed.Day() != 1:
+			result[index].days = 1
+		case parsed.Month() != time.January:
+			result[index].months = 1
+		default:
+			result[index].years = 1
+		}
+	}
+
+	return result
+}
+
+// datePeriod is the period described by a date, like a day for '2024-01-02' or 'today'. Instants like 'now' or
+// 'start_of_month' are periods that start and end at the same time, the end of those is included.
+type datePeriod struct {
+	start  time.Time
+	end    time.Time
+	closed bool
+}
+
+// instant creates a period that starts and ends at the given time
+func instant(moment time.Time) datePeriod {
+	return datePeriod{start: moment, end: moment, closed: true}
+}
+
+// shift moves the period by the number of units, which is one of s, m, h, d, w, M or y
+func (p datePeriod) shift(amount int, unit byte) (datePeriod, bool) {
+	move := func(moment time.Time) time.Time { return moment }
+
+	switch unit {
+	case 's':
+		move = func(moment time.Time) time.Time { return moment.Add(time.Duration(amount) * time.Second) }
+	case 'm':
+		move = func(moment time.Time) time.Time { return moment.Add(time.Duration(amount) * time.Minute) }
+	case 'h':
+		move = func(moment time.Time) time.Time { return moment.Add(time.Duration(amount) * time.Hour) }
+	case 'd':
+		move = func(moment time.Time) time.Time { return moment.AddDate(0, 0, amount) }
+	case 'w':
+		move = func(moment time.Time) time.Time { return moment.AddDate(0, 0, 7*amount) }
+	case 'M':
+		move = func(moment time.Time) time.Time { return moment.AddDate(0, amount, 0) }
+	case 'y':
+		move = func(moment time.Time) time.Time { return moment.AddDate(amount, 0, 0) }
+	default:
+		return datePeriod{}, false
+	}
+
+	return datePeriod{start: move(p.start), end: move(p.end), closed: p.closed}, true
+}
+
+// condition creates the condition that compares the column to the period using the operator, a date is greater than
+// a period if it's after its end and equal to it if it's between its start and end. The result is not ok if the
+// operator can't be used on periods.
+func (p datePeriod) condition(column string, operator Operator) (Condition, bool) {
+	from := Condition{Column: column, Operator: OperatorGreaterOrEqualTo, Value: p.start}
+	before := Condition{Column: column, Operator: OperatorLessThan, Value: p.start}
+
+	until := Condition{Column: column, Operator: OperatorLessThan, Value: p.end}
+	after := Condition{Column: column, Operator: OperatorGreaterOrEqualTo, Value: p.end}
+
+	if p.closed {
+		until.Operator = OperatorLessOrEqualTo
+		after.Operator = OperatorGreaterThan
+	}
+
+	switch operator {
+	case OperatorEqual:
+		if p.closed && p.start.Equal(p.end) {
+			return Condition{Column: column, Operator: OperatorEqual, Value: p.start}, true
+		}
+
+		return And(from, until), true
+	case OperatorNotEqual:
+		return Or(before, after), true
+	case OperatorGreaterThan:
+		return after, true
+	case OperatorGreaterOrEqualTo:
+		return from, true
+	case OperatorLessThan:
+		return before, true
+	case OperatorLessOrEqualTo:
+		return until, true
+	}
+
+	return Condition{}, false
+}
+
+// now returns the current time of the clock in the configured time zone
+func (d *gormQonvert) now() time.Time {
+	now := d.clock()
+	if d.location != nil {
+		now = now.In(d.location)
+	}
+
+	return now
+}
+
+// parseDate parses an absolute date like '2024-01-02' or '2024-01' using the layouts, or a relative date like
+// 'now-7d', 'today' or 'start_of_month+1w' if RelativeDates() is used. Dates without a time zone are in the
+// configured time zone.
+func (d *gormQonvert) parseDate(text string) (datePeriod, bool) {
+	now := d.now()
+
+	for _, layout := range d.dateLayouts {
+		moment, err := time.ParseInLocation(layout.layout, text, now.Location())
+		if err != nil {
+			continue
+		}
+
+		return datePeriod{start: moment, end: moment.AddDate(layout.years, layout.months, layout.days), closed: layout.days+layout.months+layout.years == 0}, true
+	}
+
+	if !d.relativeDates {
+		return datePeriod{}, false
+	}
+
+	anchorEnd := strings.IndexAny(text, "+-")
+	if anchorEnd < 0 {
+		anchorEnd = len(text)
+	}
+
+	period, ok := d.anchor(now, text[:anchorEnd])
+	if !ok {
+		return datePeriod{}, false
+	}
+
+	// The rest consists of offsets like '-7d' or '+1M'
+	for rest := text[anchorEnd:]; rest != ""; {
+		end := 1
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+
+		amount, err := strconv.Atoi(rest[:end])
+		if err != nil || end >= len(rest) {
+			return datePeriod{}, false
+		}
+
+		if period, ok = period.shift(amount, rest[end]); !ok {
+			return datePeriod{}, false
+		}
+
+		rest = rest[end+1:]
+	}
+
+	return period, true
+}
+
+// anchor returns the period of a relative date without offsets
+func (d *gormQonvert) anchor(now time.Time, name string) (datePeriod, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch name {
+	case "now":
+		return instant(now), true
+	case "today":
+		return datePeriod{start: today, end: today.AddDate(0, 0, 1)}, true
+	case "yesterday":
+		return datePeriod{start: today.AddDate(0, 0, -1), end: today}, true
+	case "tomorrow":
+		return datePeriod{start: today.AddDate(0, 0, 1), end: today.AddDate(0, 0, 2)}, true
+	case "start_of_week":
+		// Weeks start on monday
+		return instant(today.AddDate(0, 0, -(int(today.Weekday())+6)%7)), true
+	case "start_of_month":
+		return instant(today.AddDate(0, 0, 1-today.Day())), true
+	case "start_of_year":
+		return instant(today.AddDate(0, 0, 1-today.YearDay())), true
+	}
+
+	return datePeriod{}, false
+}
+
+// resolveDates replaces relative and absolute dates in the value of a condition on a time field if RelativeDates()
+// or WithDateLayouts() is used. A range like '2024-01..2024-03' or a period like 'today' can result in multiple
+// conditions. The result is not ok if nothing was replaced.
+func (d *gormQonvert) resolveDates(db *gorm.DB, condition Condition) (Condition, bool) {
+	if (!d.relativeDates && !d.absoluteDates) || db.Statement.Schema == nil {
+		return condition, false
+	}
+
+	if field, _ := d.lookUpField(db.Statement.Schema, condition.Column); field == nil || field.DataType != schema.Time {
+		return condition, false
+	}
+
+	if isSlice(condition.Value) {
+		return d.resolveDateList(condition)
+	}
+
+	text, ok := condition.Value.(string)
+	if !ok {
+		return condition, false
+	}
+
+	period, ok := d.parseDateRange(text)
+	if !ok {
+		return condition, false
+	}
+
+	return period.condition(condition.Column, condition.Operator)
+}
+
+// resolveDateList replaces the dates in the list of an IN, NOT IN or BETWEEN condition. Every date in an IN or NOT IN
+// list matches its whole period and a BETWEEN ends at the end of its last date. The result is not ok if nothing was
+// replaced.
+func (d *gormQonvert) resolveDateList(condition Condition) (Condition, bool) {
+	values := toList(condition.Value)
+
+	var resolved bool
+
+	periods := make([]*datePeriod, len(values))
+	for index, value := range values {
+		if text, ok := value.(string); ok {
+			if period, ok := d.parseDate(text); ok {
+				periods[index] = &period
+				resolved = true
+			}
+		}
+	}
+
+	if !resolved {
+		return condition, false
+	}
+
+	switch condition.Operator {
+	case OperatorBetween:
+		if len(periods) != 2 || periods[0] == nil || periods[1] == nil {
+			return condition, false
+		}
+
+		period := datePeriod{start: periods[0].start, end: periods[1].end, closed: periods[1].closed}
+
+		return period.condition(condition.Column, OperatorEqual)
+	case OperatorIn, OperatorNotIn:
+		operator, group := OperatorEqual, Or
+		if condition.Operator == OperatorNotIn {
+			operator, group = OperatorNotEqual, And
+		}
+
+		conditions := make([]Condition, len(values))
+		for index, value := range values {
+			if periods[index] == nil {
+				conditions[index] = Condition{Column: condition.Column, Operator: operator, Value: value}
+				continue
+			}
+
+			conditions[index], _ = periods[index].condition(condition.Column, operator)
+		}
+
+		return group(conditions...), true
+	}
+
+	return condition, false
+}
+
+// parseDateRange parses a date or a range of dates like 'now-7d..now', the range ends at the end of the last date
+func (d *gormQonvert) parseDateRange(text string) (datePeriod, bool) {
+	from, to, isRange := strings.Cut(text, "..")
+	if !isRange {
+		return d.parseDate(text)
+	}
+
+	start, ok := d.parseDate(from)
+	if !ok {
+		return datePeriod{}, false
+	}
+
+	end, ok := d.parseDate(to)
+	if !ok {
+		return datePeriod{}, false
+	}
+
+	return datePeriod{start: start.start, end: end.end, closed: end.closed}, true
+}
+
+// isDate returns true if the value is a date that is resolved by RelativeDates() or WithDateLayouts(), these are
+// always converted
+func (d *gormQonvert) isDate(db *gorm.DB, column string, value any) bool {
+	_, ok := d.resolveDates(db, Condition{Column: column, Operator: OperatorEqual, Value: value})
+
+	return ok
+}
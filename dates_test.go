@@ -0,0 +1,245 @@
+package gormqonvert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGormQonvert_RelativeDates_ResolvesDates(t *testing.T) {
+	t.Parallel()
+
+	type ObjectL struct {
+		Name      string
+		CreatedAt time.Time
+	}
+
+	// Friday the 15th of March
+	clock := func() time.Time { return time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC) }
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected string
+	}{
+		"now minus days": {
+			filter:   map[string]any{"created_at": ">=now-7d"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-03-08 13:30:00\"",
+		},
+		"multiple offsets": {
+			filter:   map[string]any{"created_at": "<now+1M-2h"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` < \"2024-04-15 11:30:00\"",
+		},
+		"before today": {
+			filter:   map[string]any{"created_at": "<today"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` < \"2024-03-15 00:00:00\"",
+		},
+		"after today": {
+			filter:   map[string]any{"created_at": ">today"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-03-16 00:00:00\"",
+		},
+		"today": {
+			filter:   map[string]any{"created_at": "today"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-03-15 00:00:00\" AND `object_ls`.`created_at` < \"2024-03-16 00:00:00\"",
+		},
+		"not yesterday": {
+			filter:   map[string]any{"created_at": "!=yesterday"},
+			expected: "SELECT * FROM `object_ls` WHERE (`object_ls`.`created_at` < \"2024-03-14 00:00:00\" OR `object_ls`.`created_at` >= \"2024-03-15 00:00:00\")",
+		},
+		"start of week": {
+			filter:   map[string]any{"created_at": ">=start_of_week"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-03-11 00:00:00\"",
+		},
+		"start of month": {
+			filter:   map[string]any{"created_at": ">=start_of_month"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-03-01 00:00:00\"",
+		},
+		"start of last year": {
+			filter:   map[string]any{"created_at": "<=start_of_year-1y"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` <= \"2023-01-01 00:00:00\"",
+		},
+		"range of months": {
+			filter:   map[string]any{"created_at": "2024-01..2024-03"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-01-01 00:00:00\" AND `object_ls`.`created_at` < \"2024-04-01 00:00:00\"",
+		},
+		"rolling range": {
+			filter:   map[string]any{"created_at": "now-1h..now"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"2024-03-15 12:30:00\" AND `object_ls`.`created_at` <= \"2024-03-15 13:30:00\"",
+		},
+		"list of dates": {
+			filter:   map[string]any{"created_at": []string{"<2024", "2024-03-01"}},
+			expected: "SELECT * FROM `object_ls` WHERE (`object_ls`.`created_at` < \"2024-01-01 00:00:00\" OR (`object_ls`.`created_at` >= \"2024-03-01 00:00:00\" AND `object_ls`.`created_at` < \"2024-03-02 00:00:00\"))",
+		},
+		"time zone": {
+			options:  []Option{WithLocation(time.FixedZone("UTC+12", 12*60*60))},
+			filter:   map[string]any{"created_at": "<today"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` < \"2024-03-16 00:00:00\"",
+		},
+		"not a date": {
+			filter:   map[string]any{"created_at": ">=later"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`created_at` >= \"later\"",
+		},
+		"not a time field": {
+			filter:   map[string]any{"name": "today"},
+			expected: "SELECT * FROM `object_ls` WHERE `object_ls`.`name` = \"today\"",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{
+				GreaterThanPrefix:      ">",
+				GreaterOrEqualToPrefix: ">=",
+				LessThanPrefix:         "<",
+				LessOrEqualToPrefix:    "<=",
+				NotEqualToPrefix:       "!=",
+			}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, append([]Option{RelativeDates(), WithClock(clock)}, testData.options...)...))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(&[]ObjectL{})
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormQonvert_RelativeDates_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectM struct {
+		Name      string
+		CreatedAt time.Time
+	}
+
+	clock := func() time.Time { return time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC) }
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectM{})
+	_ = db.Use(New(CharacterConfig{GreaterOrEqualToPrefix: ">="}, RelativeDates(), WithClock(clock)))
+
+	_ = db.Create([]ObjectM{
+		{Name: "old", CreatedAt: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)},
+		{Name: "new", CreatedAt: time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)},
+	}).Error
+
+	var actual []ObjectM
+
+	// Act
+	err := db.Where(map[string]any{"created_at": ">=now-7d"}).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, actual, 1) {
+		assert.Equal(t, "new", actual[0].Name)
+	}
+}
+
+func TestGormQonvert_WithDateLayouts_ParsesDates(t *testing.T) {
+	t.Parallel()
+
+	type ObjectN struct {
+		Name      string
+		CreatedAt time.Time
+	}
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected string
+	}{
+		"date only includes the whole day": {
+			options:  []Option{WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at": "<=2024-03-01"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` < \"2024-03-02 00:00:00\"",
+		},
+		"date only after the day": {
+			options:  []Option{WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at": ">2024-03-01"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` >= \"2024-03-02 00:00:00\"",
+		},
+		"rfc3339 keeps its time zone": {
+			options:  []Option{WithDateLayouts(time.RFC3339), WithLocation(time.UTC)},
+			filter:   map[string]any{"created_at": "<=2024-03-01T10:00:00+02:00"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` <= \"2024-03-01 10:00:00\"",
+		},
+		"custom layout": {
+			options:  []Option{WithDateLayouts("02.01.2006")},
+			filter:   map[string]any{"created_at": "01.03.2024"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` >= \"2024-03-01 00:00:00\" AND `object_ns`.`created_at` < \"2024-03-02 00:00:00\"",
+		},
+		"custom layout of months": {
+			options:  []Option{WithDateLayouts("01/2006")},
+			filter:   map[string]any{"created_at": "<=02/2024"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` < \"2024-03-01 00:00:00\"",
+		},
+		"no matching layout": {
+			options:  []Option{WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at": "<=2024-03"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` <= \"2024-03\"",
+		},
+		"relative dates are not enabled": {
+			options:  []Option{WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at": "<today"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` < \"today\"",
+		},
+		"relative dates use the layouts": {
+			options:  []Option{RelativeDates(), WithDateLayouts("02.01.2006")},
+			filter:   map[string]any{"created_at": "01.03.2024..today"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` >= \"2024-03-01 00:00:00\" AND `object_ns`.`created_at` < \"2024-03-16 00:00:00\"",
+		},
+		"range includes the last day": {
+			options:  []Option{DjangoKeys(), WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at__range": "2024-01-01,2024-01-31"},
+			expected: "SELECT * FROM `object_ns` WHERE `object_ns`.`created_at` >= \"2024-01-01 00:00:00\" AND `object_ns`.`created_at` < \"2024-02-01 00:00:00\"",
+		},
+		"in includes the whole days": {
+			options:  []Option{MongoOperators(), WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at": map[string]any{"$in": []any{"2024-01-01", "2024-02-01"}}},
+			expected: "SELECT * FROM `object_ns` WHERE ((`object_ns`.`created_at` >= \"2024-01-01 00:00:00\" AND `object_ns`.`created_at` < \"2024-01-02 00:00:00\") OR (`object_ns`.`created_at` >= \"2024-02-01 00:00:00\" AND `object_ns`.`created_at` < \"2024-02-02 00:00:00\"))",
+		},
+		"not in excludes the whole days": {
+			options:  []Option{MongoOperators(), WithDateLayouts(time.DateOnly)},
+			filter:   map[string]any{"created_at": map[string]any{"$nin": []any{"2024-01-01"}}},
+			expected: "SELECT * FROM `object_ns` WHERE (`object_ns`.`created_at` < \"2024-01-01 00:00:00\" OR `object_ns`.`created_at` >= \"2024-01-02 00:00:00\")",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{
+				GreaterThanPrefix:   ">",
+				LessThanPrefix:      "<",
+				LessOrEqualToPrefix: "<=",
+			}
+
+			clock := func() time.Time { return time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC) }
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, append([]Option{WithClock(clock)}, testData.options...)...))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(&[]ObjectN{})
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package gormqonvert
+
+import (
+	"strings"
+)
+
+// djangoSeparator separates the column from the lookup in keys like 'age__gte'
+const djangoSeparator = "__"
+
+// djangoLookups are the lookups that can be used in keys like 'age__gte', with the operator they represent
+var djangoLookups = map[string]Condition{
+	"exact":       {Operator: OperatorEqual},
+	"iexact":      {Operator: OperatorEqual, IgnoreCase: true},
+	"contains":    {Operator: OperatorContains},
+	"icontains":   {Operator: OperatorContains, IgnoreCase: true},
+	"startswith":  {Operator: OperatorStartsWith},
+	"istartswith": {Operator: OperatorStartsWith, IgnoreCase: true},
+	"endswith":    {Operator: OperatorEndsWith},
+	"iendswith":   {Operator: OperatorEndsWith, IgnoreCase: true},
+	"gt":          {Operator: OperatorGreaterThan},
+	"gte":         {Operator: OperatorGreaterOrEqualTo},
+	"lt":          {Operator: OperatorLessThan},
+	"lte":         {Operator: OperatorLessOrEqualTo},
+	"in":          {Operator: OperatorIn},
+	"range":       {Operator: OperatorBetween},
+	"isnull":      {Operator: OperatorIsNull},
+}
+
+// splitDjangoKey splits a key like 'age__gte' into a condition without a value, ok is false if the key does not
+// end with a known lookup
+func splitDjangoKey(key string) (Condition, bool) {
+	separator := strings.LastIndex(key, djangoSeparator)
+	if separator <= 0 {
+		return Condition{}, false
+	}
+
+	template, ok := djangoLookups[key[separator+len(djangoSeparator):]]
+	if !ok {
+		return Condition{}, false
+	}
+
+	template.Column = key[:separator]
+
+	return template, true
+}
@@ -0,0 +1,55 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDjangoKey_ReturnsExpectedCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		key        string
+		expected   Condition
+		expectedOk bool
+	}{
+		"no lookup": {
+			key: "age",
+		},
+		"unknown lookup": {
+			key: "age__between",
+		},
+		"only a lookup": {
+			key: "__gte",
+		},
+		"lookup": {
+			key:        "age__gte",
+			expected:   Condition{Column: "age", Operator: OperatorGreaterOrEqualTo},
+			expectedOk: true,
+		},
+		"case insensitive lookup": {
+			key:        "name__iexact",
+			expected:   Condition{Column: "name", Operator: OperatorEqual, IgnoreCase: true},
+			expectedOk: true,
+		},
+		"column with underscores": {
+			key:        "deleted__at__isnull",
+			expected:   Condition{Column: "deleted__at", Operator: OperatorIsNull},
+			expectedOk: true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := splitDjangoKey(testData.key)
+
+			// Assert
+			assert.Equal(t, testData.expectedOk, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
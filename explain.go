@@ -0,0 +1,66 @@
+package gormqonvert
+
+import (
+	"gorm.io/gorm"
+)
+
+// explainKey is used to record the conversions of a query that is run by Explain()
+const explainKey = tagName + ":explain"
+
+// Conversion is a part of a filter that was converted by the plugin
+type Conversion struct {
+	// Key is the key in the filter, like 'age' or 'age[gte]'
+	Key string
+
+	// Value is the value in the filter, like '>=30'
+	Value any
+
+	// Condition is what the key and value were converted into
+	Condition Condition
+}
+
+// Explanation describes the query that a filter results in
+type Explanation struct {
+	// SQL is the query with placeholders for the variables
+	SQL string
+
+	// Vars are the bound variables of the query
+	Vars []any
+
+	// Conversions are the parts of the filter that were converted, in the order in which they were converted
+	Conversions []Conversion
+}
+
+// Explain runs a query with the filter on the model in a DryRun session and returns the resulting SQL and the
+// conversions that were applied. The plugin must be registered on the database. Settings and conditions that were
+// already added to db are kept, like the setting of SettingOnly() or a scope. The error is that of the query, like
+// a *FilterError if Strict() is used, the explanation is returned regardless. Conversions that were rejected, like
+// the ones that caused the error, are left out.
+func Explain(db *gorm.DB, model any, filter map[string]any) (*Explanation, error) {
+	conversions := &[]Conversion{}
+
+	result := db.Session(&gorm.Session{DryRun: true}).
+		Set(explainKey, conversions).
+		Model(model).
+		Where(filter).
+		Find(model)
+
+	explanation := &Explanation{
+		SQL:         result.Statement.SQL.String(),
+		Vars:        result.Statement.Vars,
+		Conversions: *conversions,
+	}
+
+	return explanation, result.Error
+}
+
+// recordConversion adds the conversion to the recorder of the query if it was run by Explain()
+func recordConversion(db *gorm.DB, conversion Conversion) {
+	value, ok := db.Get(explainKey)
+	if !ok {
+		return
+	}
+
+	conversions := value.(*[]Conversion)
+	*conversions = append(*conversions, conversion)
+}
@@ -0,0 +1,196 @@
+package gormqonvert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestExplain_ReturnsExpectedExplanation(t *testing.T) {
+	t.Parallel()
+
+	type ObjectJ struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		expected *Explanation
+	}{
+		"nothing to convert": {
+			filter: map[string]any{"name": "jessica"},
+			expected: &Explanation{
+				SQL:         "SELECT * FROM `object_js` WHERE `object_js`.`name` = ?",
+				Vars:        []any{"jessica"},
+				Conversions: []Conversion{},
+			},
+		},
+		"prefixed value": {
+			filter: map[string]any{"age": ">=30", "name": "jessica"},
+			expected: &Explanation{
+				SQL:  "SELECT * FROM `object_js` WHERE `object_js`.`age` >= ? AND `object_js`.`name` = ?",
+				Vars: []any{"30", "jessica"},
+				Conversions: []Conversion{
+					{Key: "age", Value: ">=30", Condition: Condition{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"}},
+				},
+			},
+		},
+		"key": {
+			filter: map[string]any{"name[like]": []string{"j%", "a%"}},
+			expected: &Explanation{
+				SQL:  "SELECT * FROM `object_js` WHERE (`object_js`.`name` LIKE ? OR `object_js`.`name` LIKE ?)",
+				Vars: []any{"j%", "a%"},
+				Conversions: []Conversion{
+					{Key: "name[like]", Value: []any{"j%", "a%"}, Condition: Or(
+						Condition{Column: "name", Operator: OperatorLike, Value: "j%"},
+						Condition{Column: "name", Operator: OperatorLike, Value: "a%"},
+					)},
+				},
+			},
+		},
+		"alias": {
+			filter: map[string]any{"years": []string{"<18", "30"}},
+			expected: &Explanation{
+				SQL:  "SELECT * FROM `object_js` WHERE (`object_js`.`age` < ? OR `object_js`.`age` = ?)",
+				Vars: []any{"18", "30"},
+				Conversions: []Conversion{
+					{Key: "years", Value: []any{"<18", "30"}, Condition: Or(
+						Condition{Column: "age", Operator: OperatorLessThan, Value: "18"},
+						Condition{Column: "age", Operator: OperatorEqual, Value: "30"},
+					)},
+				},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{GreaterOrEqualToPrefix: ">=", LessThanPrefix: "<"}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, BracketKeys(), WithAliases(map[string]string{"years": "age"})))
+
+			// Act
+			result, err := Explain(db, &ObjectJ{}, testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestExplain_ReturnsErrorOfQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectJ struct {
+		Name string
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.Use(New(CharacterConfig{LikePrefix: "~"}, Strict()))
+
+	// Act
+	result, err := Explain(db, &ObjectJ{}, map[string]any{"nmae": "~j%"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+	assert.Empty(t, result.Conversions)
+}
+
+func TestExplain_KeepsSettingsAndConditions(t *testing.T) {
+	t.Parallel()
+
+	type ObjectJ struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		query    func(*gorm.DB) *gorm.DB
+		expected *Explanation
+	}{
+		"setting is not enabled": {
+			query: func(db *gorm.DB) *gorm.DB { return db },
+			expected: &Explanation{
+				SQL:         "SELECT * FROM `object_js` WHERE `object_js`.`age` = ?",
+				Vars:        []any{">=30"},
+				Conversions: []Conversion{},
+			},
+		},
+		"setting is enabled": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Set(tagName, true) },
+			expected: &Explanation{
+				SQL:  "SELECT * FROM `object_js` WHERE `object_js`.`age` >= ?",
+				Vars: []any{"30"},
+				Conversions: []Conversion{
+					{Key: "age", Value: ">=30", Condition: Condition{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"}},
+				},
+			},
+		},
+		"existing condition": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Set(tagName, true).Where("name = ?", "jessica") },
+			expected: &Explanation{
+				SQL:  "SELECT * FROM `object_js` WHERE name = ? AND `object_js`.`age` >= ?",
+				Vars: []any{"jessica", "30"},
+				Conversions: []Conversion{
+					{Key: "age", Value: ">=30", Condition: Condition{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"}},
+				},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(CharacterConfig{GreaterOrEqualToPrefix: ">="}, SettingOnly()))
+
+			// Act
+			result, err := Explain(testData.query(db), &ObjectJ{}, map[string]any{"age": ">=30"})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestExplain_ReturnsResolvedValues(t *testing.T) {
+	t.Parallel()
+
+	type ObjectJ struct {
+		Views     int
+		CreatedAt time.Time
+	}
+
+	// Arrange
+	clock := func() time.Time { return time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC) }
+	format := NumberFormat{Units: map[string]float64{"k": 1000}}
+
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.Use(New(CharacterConfig{GreaterOrEqualToPrefix: ">="}, RelativeDates(), WithClock(clock), WithNumberFormat(format)))
+
+	weekAgo := time.Date(2024, 3, 8, 13, 30, 0, 0, time.UTC)
+
+	// Act
+	result, err := Explain(db, &ObjectJ{}, map[string]any{"created_at": ">=now-7d", "views": ">=5k"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []any{weekAgo, int64(5000)}, result.Vars)
+	assert.Equal(t, []Conversion{
+		{Key: "created_at", Value: ">=now-7d", Condition: Condition{Column: "created_at", Operator: OperatorGreaterOrEqualTo, Value: weekAgo}},
+		{Key: "views", Value: ">=5k", Condition: Condition{Column: "views", Operator: OperatorGreaterOrEqualTo, Value: int64(5000)}},
+	}, result.Conversions)
+}
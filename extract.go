@@ -0,0 +1,93 @@
+package gormqonvert
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// ValueExtractor returns the text of a value in a filter, the result is not ok if the value is not text. Only the
+// text of values is checked for the prefixes of operators, other values are left alone.
+type ValueExtractor func(value any) (string, bool)
+
+// PlainStrings is the default ValueExtractor, it only extracts values of type string
+func PlainStrings(value any) (string, bool) {
+	text, ok := value.(string)
+
+	return text, ok
+}
+
+// ReflectStrings is a ValueExtractor that also extracts custom string types like `type Status string`, []byte,
+// driver.Valuers like sql.NullString that are valid strings, fmt.Stringers and non-nil pointers to any of these.
+func ReflectStrings(value any) (string, bool) {
+	switch value := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return value, true
+	case []byte:
+		return string(value), true
+	case driver.Valuer:
+		if isNilPointer(value) {
+			return "", false
+		}
+
+		// This is what the database would get, so it's preferred over String()
+		if result, err := value.Value(); err == nil {
+			return ReflectStrings(result)
+		}
+
+		return "", false
+	case fmt.Stringer:
+		if isNilPointer(value) {
+			return "", false
+		}
+
+		return value.String(), true
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch {
+	case reflectValue.Kind() == reflect.Pointer:
+		if reflectValue.IsNil() {
+			return "", false
+		}
+
+		return ReflectStrings(reflectValue.Elem().Interface())
+	case reflectValue.Kind() == reflect.String:
+		return reflectValue.String(), true
+	case reflectValue.Kind() == reflect.Slice && reflectValue.Type().Elem().Kind() == reflect.Uint8:
+		return string(reflectValue.Bytes()), true
+	}
+
+	return "", false
+}
+
+// extractBytes returns the text of the values of an IN-check that gorm created from a []byte or a custom type like
+// `type Raw []byte`, using the ValueExtractor. The result is not ok if one of the values is not a byte or the
+// ValueExtractor doesn't extract a []byte, like PlainStrings.
+func (d *gormQonvert) extractBytes(values []any) (string, bool) {
+	if len(values) == 0 {
+		return "", false
+	}
+
+	bytes := make([]byte, len(values))
+	for index, value := range values {
+		character, ok := value.(byte)
+		if !ok {
+			return "", false
+		}
+
+		bytes[index] = character
+	}
+
+	return d.extractValue(bytes)
+}
+
+// isNilPointer returns true if the value is a pointer that is nil, calling methods on those could panic
+func isNilPointer(value any) bool {
+	reflectValue := reflect.ValueOf(value)
+
+	return reflectValue.Kind() == reflect.Pointer && reflectValue.IsNil()
+}
@@ -0,0 +1,156 @@
+package gormqonvert
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type testStatus string
+
+type testRaw []byte
+
+type testName struct {
+	first string
+}
+
+func (n testName) String() string {
+	return n.first
+}
+
+func TestReflectStrings_ReturnsExpectedText(t *testing.T) {
+	t.Parallel()
+
+	text := "~jes"
+	status := testStatus("~active")
+
+	var nilText *string
+	var nilName *testName
+
+	tests := map[string]struct {
+		value    any
+		expected string
+		ok       bool
+	}{
+		"string":                {value: "~jes", expected: "~jes", ok: true},
+		"custom string type":    {value: status, expected: "~active", ok: true},
+		"pointer to string":     {value: &text, expected: "~jes", ok: true},
+		"pointer to custom":     {value: &status, expected: "~active", ok: true},
+		"nil pointer":           {value: nilText, ok: false},
+		"bytes":                 {value: []byte("~jes"), expected: "~jes", ok: true},
+		"custom bytes":          {value: testRaw("~jes"), expected: "~jes", ok: true},
+		"valid null string":     {value: sql.NullString{String: "~jes", Valid: true}, expected: "~jes", ok: true},
+		"invalid null string":   {value: sql.NullString{String: "~jes"}, ok: false},
+		"null int":              {value: sql.NullInt64{Int64: 5, Valid: true}, ok: false},
+		"stringer":              {value: testName{first: "~jes"}, expected: "~jes", ok: true},
+		"nil pointer stringer":  {value: nilName, ok: false},
+		"number":                {value: 5, ok: false},
+		"nil":                   {value: nil, ok: false},
+		"slice of other things": {value: []int{5}, ok: false},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := ReflectStrings(testData.value)
+
+			// Assert
+			assert.Equal(t, testData.ok, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormQonvert_WithValueExtractor_ConvertsValues(t *testing.T) {
+	t.Parallel()
+
+	type ObjectP struct {
+		Name   string
+		Status string
+	}
+
+	text := "~jes%"
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected string
+	}{
+		"custom string types are ignored by default": {
+			filter:   map[string]any{"status": testStatus("!=active")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`status` = \"!=active\"",
+		},
+		"custom string type": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"status": testStatus("!=active")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`status` != \"active\"",
+		},
+		"pointer to string": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"name": &text},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`name` LIKE \"jes%\"",
+		},
+		"null string": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"name": sql.NullString{String: "~jes%", Valid: true}},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`name` LIKE \"jes%\"",
+		},
+		"bytes are ignored by default": {
+			filter:   map[string]any{"name": []byte("~j")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`name` IN (126,106)",
+		},
+		"bytes": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"name": []byte("~jes%")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`name` LIKE \"jes%\"",
+		},
+		"custom bytes": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"status": testRaw("!=active")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`status` != \"active\"",
+		},
+		"bytes without prefix": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"name": []byte("jessica")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`name` = \"jessica\"",
+		},
+		"members of a list": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"status": []any{testStatus("!=active"), []byte("~old%")}},
+			expected: "SELECT * FROM `object_ps` WHERE (`object_ps`.`status` != \"active\" OR `object_ps`.`status` LIKE \"old%\")",
+		},
+		"without prefix": {
+			options:  []Option{WithValueExtractor(ReflectStrings)},
+			filter:   map[string]any{"status": testStatus("active")},
+			expected: "SELECT * FROM `object_ps` WHERE `object_ps`.`status` = \"active\"",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{
+				LikePrefix:       "~",
+				NotEqualToPrefix: "!=",
+			}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, testData.options...))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(&[]ObjectP{})
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+// Parse turns a filter map into conditions the same way the plugin would convert it in a WHERE-call. Every key
+// results in one condition, lists of values become an Or-condition of its values.
+func Parse(config CharacterConfig, filter map[string]any) []Condition {
+	keys := sortedKeys(filter)
+
+	result := make([]Condition, 0, len(keys))
+
+	for _, key := range keys {
+		values, ok := filterValues(filter[key])
+		if !ok {
+			continue
+		}
+
+		alternatives := make([]Condition, len(values))
+		for index, value := range values {
+			alternatives[index] = parseCondition(config, key, value)
+		}
+
+		if len(alternatives) == 1 {
+			result = append(result, alternatives[0])
+			continue
+		}
+
+		result = append(result, Or(alternatives...))
+	}
+
+	return result
+}
+
+// parseCondition creates a condition from a single value, values without a known prefix are considered equal-checks
+func parseCondition(config CharacterConfig, column string, value any) Condition {
+	stringValue, ok := value.(string)
+	if !ok {
+		return Condition{Column: column, Operator: OperatorEqual, Value: value}
+	}
+
+	operator, stringValue, _ := config.parse(stringValue)
+	if operator == "" {
+		operator = OperatorEqual
+	}
+
+	return Condition{Column: column, Operator: operator, Value: stringValue}
+}
+
+// Format is the reverse of Parse and turns conditions into a filter map using the prefixes of the config. Values
+// that would be mistaken for a prefixed value are escaped using the EqualToPrefix.
+//
+// A map can only hold one condition per column, so conditions that can't be represented are left out. This happens
+// if a column was already used by an earlier condition, if an operator has no configured prefix, if a condition
+// ignores case or has an escaped pattern, if an escape is required without an EqualToPrefix, if a value would be read
+// back with another prefix or if an Or-condition spans multiple columns.
+func Format(config CharacterConfig, conditions []Condition) map[string]any {
+	result := map[string]any{}
+
+	for _, condition := range flattenAnd(conditions) {
+		// An IN-condition is the same as a list of plain values
+		if condition.Operator == OperatorIn {
+			condition = inAlternatives(condition)
+		}
+
+		if condition.Operator != OperatorOr {
+			if _, ok := result[condition.Column]; ok {
+				continue
+			}
+
+			if value, ok := formatValue(config, condition); ok {
+				result[condition.Column] = value
+			}
+
+			continue
+		}
+
+		if len(condition.Conditions) == 0 {
+			continue
+		}
+
+		column := condition.Conditions[0].Column
+		if _, ok := result[column]; ok {
+			continue
+		}
+
+		if values, ok := formatValues(config, column, condition.Conditions); ok {
+			result[column] = values
+		}
+	}
+
+	return result
+}
+
+// flattenAnd replaces And-conditions with the conditions they contain, since a list of conditions already means that
+// all of them must match
+func flattenAnd(conditions []Condition) []Condition {
+	result := make([]Condition, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition.Operator == OperatorAnd {
+			result = append(result, flattenAnd(condition.Conditions)...)
+			continue
+		}
+
+		result = append(result, condition)
+	}
+
+	return result
+}
+
+// inAlternatives turns an IN-condition into an Or-condition of equal-checks
+func inAlternatives(condition Condition) Condition {
+	values := toList(condition.Value)
+
+	alternatives := make([]Condition, len(values))
+	for index, value := range values {
+		alternatives[index] = Condition{Column: condition.Column, Operator: OperatorEqual, Value: value}
+	}
+
+	return Or(alternatives...)
+}
+
+// formatValues formats the alternatives of an Or-condition into a list, strings only result in a []string
+func formatValues(config CharacterConfig, column string, alternatives []Condition) (any, bool) {
+	values := make([]any, len(alternatives))
+	stringValues := make([]string, len(alternatives))
+	onlyStrings := true
+
+	for index, alternative := range alternatives {
+		if alternative.Column != column || alternative.Conditions != nil {
+			return nil, false
+		}
+
+		value, ok := formatValue(config, alternative)
+		if !ok {
+			return nil, false
+		}
+
+		values[index] = value
+		stringValues[index], ok = value.(string)
+		onlyStrings = onlyStrings && ok
+	}
+
+	if onlyStrings {
+		return stringValues, true
+	}
+
+	return values, true
+}
+
+// formatValue adds the prefix of the condition's operator to its value, non-string values that are checked for
+// equality are left untouched
+func formatValue(config CharacterConfig, condition Condition) (any, bool) {
+	if condition.IgnoreCase || condition.Escaped {
+		return nil, false
+	}
+
+	if condition.Operator != OperatorEqual {
+		prefix, ok := config.prefix(condition.Operator)
+		if !ok {
+			return nil, false
+		}
+
+		return prefixValue(config, prefix, condition.Operator, fmt.Sprint(condition.Value))
+	}
+
+	value, ok := condition.Value.(string)
+	if !ok {
+		return condition.Value, true
+	}
+
+	// The value needs to be escaped to prevent a prefix from being recognised
+	if _, _, ok := config.parse(value); ok {
+		if config.EqualToPrefix == "" {
+			return nil, false
+		}
+
+		return prefixValue(config, config.EqualToPrefix, OperatorEqual, value)
+	}
+
+	return value, true
+}
+
+// prefixValue adds the prefix to the value, ok is false if the result is read back as another operator or value.
+// This happens if the value starts with the rest of a longer prefix, like '=5' after '>' in '>=5'.
+func prefixValue(config CharacterConfig, prefix string, operator Operator, value string) (any, bool) {
+	result := prefix + value
+
+	if parsedOperator, parsedValue, _ := config.parse(result); parsedOperator != operator || parsedValue != value {
+		return nil, false
+	}
+
+	return result, true
+}
+
+// EncodeQuery formats the conditions using Format and encodes them into a URL query string, lists are added
+// as repeated keys.
+func EncodeQuery(config CharacterConfig, conditions []Condition) string {
+	values := url.Values{}
+
+	for key, value := range Format(config, conditions) {
+		reflectValue := reflect.ValueOf(value)
+		if reflectValue.Kind() != reflect.Slice {
+			values.Set(key, fmt.Sprint(value))
+			continue
+		}
+
+		for index := 0; index < reflectValue.Len(); index++ {
+			values.Add(key, fmt.Sprint(reflectValue.Index(index).Interface()))
+		}
+	}
+
+	return values.Encode()
+}
+
+// DecodeQuery is the reverse of EncodeQuery and parses a URL query string into conditions, since URLs only contain
+// text all values are strings afterwards.
+func DecodeQuery(config CharacterConfig, query string) ([]Condition, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(config, FromValues(values, ReservedKeys())), nil
+}
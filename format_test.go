@@ -0,0 +1,287 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var formatTestConfig = CharacterConfig{
+	GreaterThanPrefix:      ">",
+	GreaterOrEqualToPrefix: ">=",
+	LessThanPrefix:         "<",
+	LessOrEqualToPrefix:    "<=",
+	NotEqualToPrefix:       "!=",
+	LikePrefix:             "~",
+	NotLikePrefix:          "!~",
+	EqualToPrefix:          "=",
+}
+
+func TestParse_ReturnsExpectedConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   map[string]any
+		expected []Condition
+	}{
+		"nothing": {
+			filter:   map[string]any{},
+			expected: []Condition{},
+		},
+		"plain values": {
+			filter: map[string]any{"name": "jessica", "age": 30},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorEqual, Value: 30},
+				{Column: "name", Operator: OperatorEqual, Value: "jessica"},
+			},
+		},
+		"prefixed values": {
+			filter: map[string]any{"age": ">=30", "name": "!~%a%"},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"},
+				{Column: "name", Operator: OperatorNotLike, Value: "%a%"},
+			},
+		},
+		"escaped value": {
+			filter: map[string]any{"name": "=~amy"},
+			expected: []Condition{
+				{Column: "name", Operator: OperatorEqual, Value: "~amy"},
+			},
+		},
+		"single list value": {
+			filter: map[string]any{"age": []string{"<30"}},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorLessThan, Value: "30"},
+			},
+		},
+		"list values": {
+			filter: map[string]any{"age": []any{"<30", ">35", 33}},
+			expected: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorLessThan, Value: "30"},
+					Condition{Column: "age", Operator: OperatorGreaterThan, Value: "35"},
+					Condition{Column: "age", Operator: OperatorEqual, Value: 33},
+				),
+			},
+		},
+		"empty list": {
+			filter:   map[string]any{"age": []string{}},
+			expected: []Condition{},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := Parse(formatTestConfig, testData.filter)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestFormat_ReturnsExpectedFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config     CharacterConfig
+		conditions []Condition
+		expected   map[string]any
+	}{
+		"nothing": {
+			config:   formatTestConfig,
+			expected: map[string]any{},
+		},
+		"plain values": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				{Column: "age", Operator: OperatorEqual, Value: 30},
+				{Column: "name", Operator: OperatorEqual, Value: "jessica"},
+			},
+			expected: map[string]any{"name": "jessica", "age": 30},
+		},
+		"prefixed values": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: 30},
+				{Column: "name", Operator: OperatorNotLike, Value: "%a%"},
+			},
+			expected: map[string]any{"age": ">=30", "name": "!~%a%"},
+		},
+		"escaped value": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				{Column: "name", Operator: OperatorEqual, Value: "<3"},
+			},
+			expected: map[string]any{"name": "=<3"},
+		},
+		"value that can't be escaped": {
+			config: CharacterConfig{LessThanPrefix: "<"},
+			conditions: []Condition{
+				{Column: "name", Operator: OperatorEqual, Value: "<3"},
+			},
+			expected: map[string]any{},
+		},
+		"value that is read back with another prefix": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				{Column: "name", Operator: OperatorGreaterThan, Value: "=5"},
+			},
+			expected: map[string]any{},
+		},
+		"operator without prefix": {
+			config: CharacterConfig{LessThanPrefix: "<"},
+			conditions: []Condition{
+				{Column: "name", Operator: OperatorLike, Value: "%a%"},
+				{Column: "age", Operator: OperatorLessThan, Value: 30},
+			},
+			expected: map[string]any{"age": "<30"},
+		},
+		"or-condition": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorLessThan, Value: 30},
+					Condition{Column: "age", Operator: OperatorGreaterThan, Value: 35},
+				),
+			},
+			expected: map[string]any{"age": []string{"<30", ">35"}},
+		},
+		"or-condition with native values": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorLessThan, Value: 30},
+					Condition{Column: "age", Operator: OperatorEqual, Value: 33},
+				),
+			},
+			expected: map[string]any{"age": []any{"<30", 33}},
+		},
+		"in-condition": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				{Column: "name", Operator: OperatorIn, Value: []any{"amy", "~boris"}},
+			},
+			expected: map[string]any{"name": []string{"amy", "=~boris"}},
+		},
+		"or-condition over multiple columns": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorLessThan, Value: 30},
+					Condition{Column: "name", Operator: OperatorEqual, Value: "amy"},
+				),
+			},
+			expected: map[string]any{},
+		},
+		"same column twice": {
+			config: formatTestConfig,
+			conditions: []Condition{
+				{Column: "age", Operator: OperatorGreaterThan, Value: 30},
+				{Column: "age", Operator: OperatorLessThan, Value: 35},
+			},
+			expected: map[string]any{"age": ">30"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := Format(testData.config, testData.conditions)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestEncodeQuery_ReturnsExpectedQuery(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	conditions := []Condition{
+		{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: 30},
+		{Column: "name", Operator: OperatorEqual, Value: "~amy"},
+		Or(
+			Condition{Column: "city", Operator: OperatorLike, Value: "A%"},
+			Condition{Column: "city", Operator: OperatorEqual, Value: "Utrecht"},
+		),
+	}
+
+	// Act
+	result := EncodeQuery(formatTestConfig, conditions)
+
+	// Assert
+	assert.Equal(t, "age=%3E%3D30&city=~A%25&city=Utrecht&name=%3D~amy", result)
+}
+
+func TestDecodeQuery_ReturnsErrorOnInvalidQuery(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, err := DecodeQuery(formatTestConfig, "age=%zz")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestDecodeQuery_SurvivesRoundTrip(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	conditions := []Condition{
+		{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"},
+		Or(
+			Condition{Column: "city", Operator: OperatorLike, Value: "A%"},
+			Condition{Column: "city", Operator: OperatorEqual, Value: "Utrecht"},
+		),
+		{Column: "name", Operator: OperatorEqual, Value: "~amy"},
+	}
+
+	// Act
+	result, err := DecodeQuery(formatTestConfig, EncodeQuery(formatTestConfig, conditions))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, conditions, result)
+}
+
+func TestFormat_SurvivesRoundTripThroughDatabase(t *testing.T) {
+	t.Parallel()
+
+	type ObjectC struct {
+		Name string
+		Age  int
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectC{})
+	_ = db.Use(New(formatTestConfig))
+
+	existing := []ObjectC{{Name: "~amy", Age: 30}, {Name: "amy", Age: 30}, {Name: "~amy", Age: 20}}
+	if err := db.CreateInBatches(existing, 10).Error; err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	conditions := []Condition{
+		{Column: "name", Operator: OperatorEqual, Value: "~amy"},
+		{Column: "age", Operator: OperatorGreaterThan, Value: 25},
+	}
+
+	query, err := DecodeQuery(formatTestConfig, EncodeQuery(formatTestConfig, conditions))
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectC
+	err = db.Where(Format(formatTestConfig, query)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectC{{Name: "~amy", Age: 30}}, actual)
+}
@@ -0,0 +1,96 @@
+package gormqonvert
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ConvertHook is called for every condition that a filter was converted into, rawValue is the value of the key in the
+// filter and convertedValue the one that's compared to the column. Keys with multiple values result in multiple calls.
+type ConvertHook func(ctx context.Context, column string, operator Operator, rawValue any, convertedValue any)
+
+// queryConversionsKey is used to collect the conversions of a statement for the query hooks
+const queryConversionsKey = tagName + ":conversions"
+
+// QueryHook is called once per query with all conversions of its filter
+type QueryHook func(ctx context.Context, conversions []Conversion)
+
+// SkipHook is called for every part of a filter that was left alone, like values without a prefix
+type SkipHook func(ctx context.Context, column string, value any)
+
+// OnConvert registers a hook that's called for every converted condition, for example for auditing or metrics.
+// Hooks are called in the order in which they were registered. Conversions that were rejected by Strict(),
+// WithLimits() or RequireIndexes() are not passed to the hooks.
+func OnConvert(hook ConvertHook) Option {
+	return func(like *gormQonvert) {
+		like.convertHooks = append(like.convertHooks, hook)
+	}
+}
+
+// OnQuery registers a hook that's called once per query after its filter was converted, with all conversions that
+// were applied. Queries without conversions are skipped.
+func OnQuery(hook QueryHook) Option {
+	return func(like *gormQonvert) {
+		like.queryHooks = append(like.queryHooks, hook)
+	}
+}
+
+// OnSkip registers a hook that's called for every part of a filter that was not converted
+func OnSkip(hook SkipHook) Option {
+	return func(like *gormQonvert) {
+		like.skipHooks = append(like.skipHooks, hook)
+	}
+}
+
+// notifyConvert calls the convert hooks for every condition in the conversion that isn't a group
+func (d *gormQonvert) notifyConvert(db *gorm.DB, conversion Conversion) {
+	if len(d.queryHooks) > 0 {
+		var conversions []Conversion
+		if value, ok := db.InstanceGet(queryConversionsKey); ok {
+			conversions = value.([]Conversion)
+		}
+
+		db.InstanceSet(queryConversionsKey, append(conversions, conversion))
+	}
+
+	if len(d.convertHooks) == 0 {
+		return
+	}
+
+	var notify func(condition Condition)
+	notify = func(condition Condition) {
+		if condition.Conditions != nil {
+			for _, nested := range condition.Conditions {
+				notify(nested)
+			}
+
+			return
+		}
+
+		for _, hook := range d.convertHooks {
+			hook(db.Statement.Context, condition.Column, condition.Operator, conversion.Value, condition.Value)
+		}
+	}
+
+	notify(conversion.Condition)
+}
+
+// notifyQuery calls the query hooks with the conversions of the query
+func (d *gormQonvert) notifyQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(queryConversionsKey)
+	if !ok {
+		return
+	}
+
+	for _, hook := range d.queryHooks {
+		hook(db.Statement.Context, value.([]Conversion))
+	}
+}
+
+// notifySkip calls the skip hooks
+func (d *gormQonvert) notifySkip(db *gorm.DB, column string, value any) {
+	for _, hook := range d.skipHooks {
+		hook(db.Statement.Context, column, value)
+	}
+}
@@ -0,0 +1,235 @@
+package gormqonvert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGormQonvert_Hooks_AreCalled(t *testing.T) {
+	t.Parallel()
+
+	type ObjectK struct {
+		Name string
+		Age  int
+	}
+
+	type call struct {
+		column         string
+		operator       Operator
+		rawValue       any
+		convertedValue any
+	}
+
+	tests := map[string]struct {
+		filter          map[string]any
+		expectedConvert []call
+		expectedSkip    []call
+	}{
+		"nothing": {
+			filter: map[string]any{},
+		},
+		"prefixed value": {
+			filter:          map[string]any{"age": ">=30"},
+			expectedConvert: []call{{column: "age", operator: OperatorGreaterOrEqualTo, rawValue: ">=30", convertedValue: "30"}},
+		},
+		"multiple values": {
+			filter: map[string]any{"name": []string{"~j%", "amy"}},
+			expectedConvert: []call{
+				{column: "name", operator: OperatorLike, rawValue: []any{"~j%", "amy"}, convertedValue: "j%"},
+				{column: "name", operator: OperatorEqual, rawValue: []any{"~j%", "amy"}, convertedValue: "amy"},
+			},
+		},
+		"skipped values": {
+			filter: map[string]any{"age": 30, "name": []string{"jessica", "amy"}},
+			expectedSkip: []call{
+				{column: "age", rawValue: 30},
+				{column: "name", rawValue: []any{"jessica", "amy"}},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var converted, skipped []call
+
+			onConvert := func(_ context.Context, column string, operator Operator, rawValue any, convertedValue any) {
+				converted = append(converted, call{column: column, operator: operator, rawValue: rawValue, convertedValue: convertedValue})
+			}
+
+			onSkip := func(_ context.Context, column string, value any) {
+				skipped = append(skipped, call{column: column, rawValue: value})
+			}
+
+			config := CharacterConfig{GreaterOrEqualToPrefix: ">=", LikePrefix: "~"}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectK{})
+			_ = db.Use(New(config, OnConvert(onConvert), OnSkip(onSkip)))
+
+			// Act
+			err := db.Where(testData.filter).Find(&[]ObjectK{}).Error
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expectedConvert, converted)
+			assert.Equal(t, testData.expectedSkip, skipped)
+		})
+	}
+}
+
+func TestGormQonvert_OnQuery_IsCalledOncePerQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectK struct {
+		Name string
+		Age  int
+	}
+
+	// Arrange
+	var calls [][]Conversion
+
+	onQuery := func(_ context.Context, conversions []Conversion) {
+		calls = append(calls, conversions)
+	}
+
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectK{})
+	_ = db.Use(New(CharacterConfig{GreaterOrEqualToPrefix: ">="}, OnQuery(onQuery)))
+
+	// Act
+	err := db.Where(map[string]any{"age": ">=30", "name": "jessica"}).Find(&[]ObjectK{}).Error
+	_ = db.Where(map[string]any{"name": "jessica"}).Find(&[]ObjectK{}).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, [][]Conversion{{{Key: "age", Value: ">=30", Condition: Condition{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"}}}}, calls)
+}
+
+func TestGormQonvert_Hooks_AreNotCalledForRejectedConditions(t *testing.T) {
+	t.Parallel()
+
+	type ObjectK struct {
+		Name string
+		Age  int
+	}
+
+	type call struct {
+		column         string
+		operator       Operator
+		convertedValue any
+	}
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected []call
+	}{
+		"unknown column in strict mode": {
+			options:  []Option{Strict()},
+			filter:   map[string]any{"age": ">=30", "nmae": "~j%"},
+			expected: []call{{column: "age", operator: OperatorGreaterOrEqualTo, convertedValue: "30"}},
+		},
+		"too many conditions": {
+			options:  []Option{WithLimits(Limits{Conditions: 1})},
+			filter:   map[string]any{"age": ">=30", "name": "~j%"},
+			expected: []call{{column: "age", operator: OperatorGreaterOrEqualTo, convertedValue: "30"}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var converted []call
+			var queried [][]Conversion
+
+			onConvert := func(_ context.Context, column string, operator Operator, _ any, convertedValue any) {
+				converted = append(converted, call{column: column, operator: operator, convertedValue: convertedValue})
+			}
+
+			onQuery := func(_ context.Context, conversions []Conversion) {
+				queried = append(queried, conversions)
+			}
+
+			config := CharacterConfig{GreaterOrEqualToPrefix: ">=", LikePrefix: "~"}
+			options := append([]Option{OnConvert(onConvert), OnQuery(onQuery)}, testData.options...)
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectK{})
+			_ = db.Use(New(config, options...))
+
+			// Act
+			err := db.Where(testData.filter).Find(&[]ObjectK{}).Error
+
+			// Assert
+			assert.Error(t, err)
+			assert.Equal(t, testData.expected, converted)
+			assert.Equal(t, [][]Conversion{{{Key: "age", Value: ">=30", Condition: Condition{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"}}}}, queried)
+		})
+	}
+}
+
+func TestGormQonvert_Hooks_ReceiveResolvedValues(t *testing.T) {
+	t.Parallel()
+
+	type ObjectK struct {
+		Views     int
+		CreatedAt time.Time
+	}
+
+	type call struct {
+		column         string
+		operator       Operator
+		rawValue       any
+		convertedValue any
+	}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		expected []call
+	}{
+		"relative date": {
+			filter:   map[string]any{"created_at": ">=now-7d"},
+			expected: []call{{column: "created_at", operator: OperatorGreaterOrEqualTo, rawValue: ">=now-7d", convertedValue: time.Date(2024, 3, 8, 13, 30, 0, 0, time.UTC)}},
+		},
+		"unit": {
+			filter:   map[string]any{"views": ">=5k"},
+			expected: []call{{column: "views", operator: OperatorGreaterOrEqualTo, rawValue: ">=5k", convertedValue: int64(5000)}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var converted []call
+
+			onConvert := func(_ context.Context, column string, operator Operator, rawValue any, convertedValue any) {
+				converted = append(converted, call{column: column, operator: operator, rawValue: rawValue, convertedValue: convertedValue})
+			}
+
+			clock := func() time.Time { return time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC) }
+			format := NumberFormat{Units: map[string]float64{"k": 1000}}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectK{})
+			_ = db.Use(New(CharacterConfig{GreaterOrEqualToPrefix: ">="}, RelativeDates(), WithClock(clock), WithNumberFormat(format), OnConvert(onConvert)))
+
+			// Act
+			err := db.Where(testData.filter).Find(&[]ObjectK{}).Error
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, converted)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package gormqonvert
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrNotIndexed is added to a query if RequireIndexes() is used and it filters on a column without an index
+	ErrNotIndexed = errors.New("column is not indexed")
+
+	// ErrLeadingWildcard is added to a query if RejectLeadingWildcards() is used and it has a LIKE-pattern that
+	// starts with a wildcard
+	ErrLeadingWildcard = errors.New("pattern starts with a wildcard")
+)
+
+// checkIndex reports conditions that can't use an index, the result is false if the condition was rejected
+func (d *gormQonvert) checkIndex(db *gorm.DB, condition Condition) bool {
+	if d.requireIndexes && !d.isIndexed(db, condition.Column) {
+		return d.reportIndex(db, fmt.Errorf("%w: '%s'", ErrNotIndexed, condition.Column))
+	}
+
+	if d.rejectLeadingWildcards && hasLeadingWildcard(condition) {
+		return d.reportIndex(db, fmt.Errorf("%w: '%v' in '%s'", ErrLeadingWildcard, condition.Value, condition.Column))
+	}
+
+	return true
+}
+
+// reportIndex logs the error if LogUnindexed() is used and adds it to the query otherwise
+func (d *gormQonvert) reportIndex(db *gorm.DB, err error) bool {
+	if d.logUnindexed {
+		db.Logger.Warn(db.Statement.Context, err.Error())
+		return true
+	}
+
+	_ = db.AddError(err)
+
+	return false
+}
+
+// isIndexed returns true if the column was given to RequireIndexes() or has an index in the model
+func (d *gormQonvert) isIndexed(db *gorm.DB, name string) bool {
+	if d.indexedColumns[name] {
+		return true
+	}
+
+	if db.Statement.Schema == nil {
+		return false
+	}
+
+	return d.isIndexedField(db.Statement.Schema, name)
+}
+
+// isIndexedField returns true if the field is a primary key, unique or the first field of an index, since other
+// fields of an index can't be used on their own. Columns of relations are checked using the related schema.
+func (d *gormQonvert) isIndexedField(modelSchema *schema.Schema, name string) bool {
+	if d.relationKeys {
+		if relation, rest, ok := findRelation(modelSchema, name); ok {
+			return d.isIndexedField(relation.FieldSchema, rest)
+		}
+	}
+
+	field := modelSchema.LookUpField(name)
+	if field == nil {
+		return false
+	}
+
+	if field.PrimaryKey || field.Unique {
+		return true
+	}
+
+	for _, index := range modelSchema.ParseIndexes() {
+		if len(index.Fields) > 0 && index.Fields[0].Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasLeadingWildcard returns true if the condition uses a LIKE-pattern that starts with a wildcard
+func hasLeadingWildcard(condition Condition) bool {
+	switch condition.Operator {
+	case OperatorContains, OperatorEndsWith:
+		return true
+	case OperatorLike, OperatorNotLike:
+		for _, value := range toList(condition.Value) {
+			if pattern := fmt.Sprint(value); pattern != "" && (pattern[0] == '%' || pattern[0] == '_') {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rejectedExpression replaces conditions that were rejected, the query fails anyway because of the error
+var rejectedExpression = clause.Expr{SQL: "1 = 0"}
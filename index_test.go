@@ -0,0 +1,143 @@
+package gormqonvert
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/logger"
+)
+
+type indexCustomer struct {
+	ID      int
+	Email   string `gorm:"unique"`
+	Name    string `gorm:"index"`
+	Country string `gorm:"index:idx_location,priority:1"`
+	City    string `gorm:"index:idx_location,priority:2"`
+	Notes   string
+	Orders  []indexOrder `gorm:"foreignKey:CustomerID"`
+}
+
+type indexOrder struct {
+	ID         int
+	CustomerID int `gorm:"index"`
+	Amount     int
+}
+
+func TestGormQonvert_RequireIndexes_RejectsUnindexedColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected error
+	}{
+		"primary key": {
+			options: []Option{RequireIndexes()},
+			filter:  map[string]any{"id": ">1"},
+		},
+		"unique field": {
+			options: []Option{RequireIndexes()},
+			filter:  map[string]any{"email": "~jes%"},
+		},
+		"index": {
+			options: []Option{RequireIndexes()},
+			filter:  map[string]any{"name": ">a"},
+		},
+		"first field of composite index": {
+			options: []Option{RequireIndexes()},
+			filter:  map[string]any{"country": ">a"},
+		},
+		"second field of composite index": {
+			options:  []Option{RequireIndexes()},
+			filter:   map[string]any{"city": ">a"},
+			expected: ErrNotIndexed,
+		},
+		"unindexed column": {
+			options:  []Option{RequireIndexes()},
+			filter:   map[string]any{"notes": "~%jes%"},
+			expected: ErrNotIndexed,
+		},
+		"unconverted condition": {
+			options: []Option{RequireIndexes()},
+			filter:  map[string]any{"notes": "jessica"},
+		},
+		"given column": {
+			options: []Option{RequireIndexes("notes")},
+			filter:  map[string]any{"notes": ">a"},
+		},
+		"indexed column of relation": {
+			options: []Option{RelationKeys(), RequireIndexes()},
+			filter:  map[string]any{"orders.customer_id": ">1"},
+		},
+		"unindexed column of relation": {
+			options:  []Option{RelationKeys(), RequireIndexes()},
+			filter:   map[string]any{"orders.amount": ">1"},
+			expected: ErrNotIndexed,
+		},
+		"leading wildcard": {
+			options:  []Option{RejectLeadingWildcards()},
+			filter:   map[string]any{"notes": "~%jes"},
+			expected: ErrLeadingWildcard,
+		},
+		"leading wildcard in list": {
+			options:  []Option{RejectLeadingWildcards()},
+			filter:   map[string]any{"notes": []string{"~jes%", "~_my"}},
+			expected: ErrLeadingWildcard,
+		},
+		"leading wildcard on indexed column": {
+			options:  []Option{RequireIndexes(), RejectLeadingWildcards()},
+			filter:   map[string]any{"name": "~%jes"},
+			expected: ErrLeadingWildcard,
+		},
+		"trailing wildcard": {
+			options: []Option{RejectLeadingWildcards()},
+			filter:  map[string]any{"notes": "~jes%"},
+		},
+		"logged": {
+			options: []Option{RequireIndexes(), LogUnindexed()},
+			filter:  map[string]any{"notes": ">a"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{LikePrefix: "~", GreaterThanPrefix: ">"}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&indexCustomer{}, &indexOrder{})
+			_ = db.Use(New(config, testData.options...))
+
+			// Act
+			err := db.Where(testData.filter).Find(&[]indexCustomer{}).Error
+
+			// Assert
+			assert.ErrorIs(t, err, testData.expected)
+		})
+	}
+}
+
+func TestGormQonvert_LogUnindexed_LogsWarning(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var output bytes.Buffer
+
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&indexCustomer{})
+	_ = db.Use(New(CharacterConfig{GreaterThanPrefix: ">"}, RequireIndexes(), LogUnindexed()))
+
+	db.Logger = logger.New(log.New(&output, "", 0), logger.Config{LogLevel: logger.Warn})
+
+	// Act
+	err := db.Where(map[string]any{"notes": ">a"}).Find(&[]indexCustomer{}).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "column is not indexed: 'notes'")
+}
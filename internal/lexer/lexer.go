@@ -0,0 +1,127 @@
+// Package lexer contains the tokens, errors and token cursor that the filter languages of this module share, the
+// languages only add the scanning of their own tokens.
+package lexer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// whitespace separates tokens and is skipped
+const whitespace = " \t\r\n"
+
+// SyntaxError is returned if a filter could not be parsed, Position is the byte offset in the filter at which
+// the problem was found.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// Errorf creates a SyntaxError at the position
+func Errorf(position int, format string, args ...any) error {
+	return &SyntaxError{Position: position, Message: fmt.Sprintf(format, args...)}
+}
+
+// IsWhitespace returns true if the character separates tokens
+func IsWhitespace(character byte) bool {
+	return strings.IndexByte(whitespace, character) >= 0
+}
+
+// Kind is the type of a token
+type Kind int
+
+const (
+	End Kind = iota
+	Text
+	String
+	Number
+	Comparator
+	LeftParenthesis
+	RightParenthesis
+	Comma
+)
+
+// Token is a part of a filter, Position is the byte offset in the filter at which it starts
+type Token struct {
+	Kind     Kind
+	Text     string
+	Position int
+}
+
+// Is returns true if the token is the given keyword, keywords are case-sensitive
+func (t Token) Is(keyword string) bool {
+	return t.Kind == Text && t.Text == keyword
+}
+
+// ScanFunc scans the token that starts at the position, which is not whitespace or a parenthesis. It returns the
+// token and the position after it.
+type ScanFunc func(input string, position int) (Token, int, error)
+
+// Tokenize splits the input into tokens, whitespace and parentheses are handled here and everything else is left to
+// the scan function. The last token is always an End.
+func Tokenize(input string, scan ScanFunc) ([]Token, error) {
+	var result []Token
+
+	for position := 0; position < len(input); {
+		character := input[position]
+
+		switch {
+		case IsWhitespace(character):
+			position++
+
+		case character == '(':
+			result = append(result, Token{Kind: LeftParenthesis, Text: "(", Position: position})
+			position++
+
+		case character == ')':
+			result = append(result, Token{Kind: RightParenthesis, Text: ")", Position: position})
+			position++
+
+		default:
+			token, end, err := scan(input, position)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, token)
+			position = end
+		}
+	}
+
+	return append(result, Token{Kind: End, Position: len(input)}), nil
+}
+
+// Cursor walks through the tokens of a filter
+type Cursor struct {
+	Tokens   []Token
+	Position int
+}
+
+// Peek returns the current token
+func (c *Cursor) Peek() Token {
+	return c.Tokens[c.Position]
+}
+
+// Next returns the current token and moves on, the end token is never passed
+func (c *Cursor) Next() Token {
+	current := c.Tokens[c.Position]
+	if current.Kind != End {
+		c.Position++
+	}
+
+	return current
+}
+
+// Expect moves on if the current token is of the given kind and returns a SyntaxError otherwise
+func (c *Cursor) Expect(kind Kind, description string) error {
+	current := c.Next()
+	if current.Kind != kind {
+		return Errorf(current.Position, "expected %s", description)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scanWords reads words of letters and fails on anything else
+func scanWords(input string, position int) (Token, int, error) {
+	end := position
+	for end < len(input) && input[end] >= 'a' && input[end] <= 'z' {
+		end++
+	}
+
+	if end == position {
+		return Token{}, 0, Errorf(position, "unexpected '%c'", input[position])
+	}
+
+	return Token{Kind: Text, Text: input[position:end], Position: position}, end, nil
+}
+
+func TestTokenize_ReturnsExpectedTokens(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected []Token
+		error    string
+	}{
+		"empty": {
+			input:    "",
+			expected: []Token{{Kind: End}},
+		},
+		"words and parentheses": {
+			input: " (a bc)\n",
+			expected: []Token{
+				{Kind: LeftParenthesis, Text: "(", Position: 1},
+				{Kind: Text, Text: "a", Position: 2},
+				{Kind: Text, Text: "bc", Position: 4},
+				{Kind: RightParenthesis, Text: ")", Position: 6},
+				{Kind: End, Position: 8},
+			},
+		},
+		"error of the scan function": {
+			input: "a 1",
+			error: "unexpected '1' at position 2",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := Tokenize(testData.input, scanWords)
+
+			// Assert
+			if testData.error != "" {
+				assert.EqualError(t, err, testData.error)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestCursor_WalksThroughTokens(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	tokens, _ := Tokenize("(a", scanWords)
+	cursor := &Cursor{Tokens: tokens}
+
+	// Act
+	leftErr := cursor.Expect(LeftParenthesis, "'('")
+	word := cursor.Next()
+	rightErr := cursor.Expect(RightParenthesis, "')'")
+
+	// Assert
+	assert.NoError(t, leftErr)
+	assert.True(t, word.Is("a"))
+	assert.EqualError(t, rightErr, "expected ')' at position 2")
+	assert.Equal(t, End, cursor.Next().Kind)
+	assert.Equal(t, End, cursor.Peek().Kind)
+}
+
+func TestErrorf_ReturnsSyntaxError(t *testing.T) {
+	t.Parallel()
+	// Act
+	err := Errorf(3, "unexpected '%c'", '!')
+
+	// Assert
+	var syntaxError *SyntaxError
+	if assert.ErrorAs(t, err, &syntaxError) {
+		assert.Equal(t, 3, syntaxError.Position)
+	}
+
+	assert.EqualError(t, err, "unexpected '!' at position 3")
+}
@@ -0,0 +1,88 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// jsonAPIPrefix starts every filter parameter in the JSON:API convention
+const jsonAPIPrefix = "filter["
+
+// FromJSONAPI turns JSON:API-style query parameters like 'filter[age][gte]=30&filter[name]=jess' into conditions.
+// Operators are the same as in ParseBrackets, comma-separated values without an operator become an IN-check.
+// Parameters that don't start with 'filter' are ignored, AllowedKeys and ColumnNames apply to the field names
+// between the first brackets.
+func FromJSONAPI(values url.Values, opts ...ValuesOption) ([]Condition, error) {
+	config := newValuesConfig(opts)
+
+	keys := sortedKeys(values)
+
+	result := make([]Condition, 0, len(keys))
+
+	for _, key := range keys {
+		field, operatorName, ok := splitJSONAPIKey(key)
+		if !ok || !config.allowed(field) || len(values[key]) == 0 {
+			continue
+		}
+
+		column := config.column(field)
+
+		if operatorName == "" {
+			result = append(result, jsonAPIListCondition(column, values[key]))
+			continue
+		}
+
+		operator, ok := bracketOperators[operatorName]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator '%s' in key '%s'", operatorName, key)
+		}
+
+		condition, _ := keyCondition(Condition{Column: column, Operator: operator}, toList(values[key]))
+		result = append(result, condition)
+	}
+
+	return result, nil
+}
+
+// splitJSONAPIKey splits a key like 'filter[age][gte]' into its field and operator, the operator is empty if
+// it was not given. The result is not ok if the key is not a filter.
+func splitJSONAPIKey(key string) (string, string, bool) {
+	if !strings.HasPrefix(key, jsonAPIPrefix) {
+		return "", "", false
+	}
+
+	rest := key[len(jsonAPIPrefix):]
+
+	end := strings.IndexByte(rest, ']')
+	if end <= 0 {
+		return "", "", false
+	}
+
+	field, rest := rest[:end], rest[end+1:]
+	if rest == "" {
+		return field, "", true
+	}
+
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return "", "", false
+	}
+
+	return field, rest[1 : len(rest)-1], true
+}
+
+// jsonAPIListCondition creates an equal-check, or an IN-check if there are multiple comma-separated values
+func jsonAPIListCondition(column string, values []string) Condition {
+	var list []any
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			list = append(list, part)
+		}
+	}
+
+	if len(list) == 1 {
+		return Condition{Column: column, Operator: OperatorEqual, Value: list[0]}
+	}
+
+	return Condition{Column: column, Operator: OperatorIn, Value: list}
+}
@@ -0,0 +1,117 @@
+package gormqonvert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromJSONAPI_ReturnsExpectedConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		target   string
+		options  []ValuesOption
+		expected []Condition
+	}{
+		"nothing": {
+			target:   "/",
+			expected: []Condition{},
+		},
+		"other parameters": {
+			target:   "/?page[size]=10&sort=name&filter=name&filter[=a&filter[]=a&filter[name]x=a&filter[name]]=a",
+			expected: []Condition{},
+		},
+		"plain values": {
+			target: "/?filter[name]=jess&filter[age]=30,31&filter[city]=Utrecht&filter[city]=Delft",
+			expected: []Condition{
+				{Column: "age", Operator: OperatorIn, Value: []any{"30", "31"}},
+				{Column: "city", Operator: OperatorIn, Value: []any{"Utrecht", "Delft"}},
+				{Column: "name", Operator: OperatorEqual, Value: "jess"},
+			},
+		},
+		"operators": {
+			target: "/?filter[age][gte]=30&filter[age][lt]=40&filter[name][nin]=amy,boris&filter[name][like]=a,b",
+			expected: []Condition{
+				{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: "30"},
+				{Column: "age", Operator: OperatorLessThan, Value: "40"},
+				{Column: "name", Operator: OperatorLike, Value: "a,b"},
+				{Column: "name", Operator: OperatorNotIn, Value: []any{"amy", "boris"}},
+			},
+		},
+		"allowed keys and column names": {
+			target: "/?filter[customerName]=jess&filter[password]=secret",
+			options: []ValuesOption{
+				AllowedKeys("customerName"),
+				ColumnNames(map[string]string{"customerName": "customer_name"}),
+			},
+			expected: []Condition{
+				{Column: "customer_name", Operator: OperatorEqual, Value: "jess"},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			request := httptest.NewRequest(http.MethodGet, testData.target, nil)
+
+			// Act
+			result, err := FromJSONAPI(request.URL.Query(), testData.options...)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestFromJSONAPI_ReturnsErrorOnUnknownOperator(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	request := httptest.NewRequest(http.MethodGet, "/?filter[age][between]=1,2", nil)
+
+	// Act
+	result, err := FromJSONAPI(request.URL.Query())
+
+	// Assert
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "unknown operator 'between' in key 'filter[age][between]'")
+}
+
+func TestFromJSONAPI_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectG struct {
+		Name string
+		Age  int
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectG{})
+
+	existing := []ObjectG{{Name: "jessica", Age: 29}, {Name: "amy", Age: 30}, {Name: "boris", Age: 31}}
+	if err := db.CreateInBatches(existing, 10).Error; err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/?filter[age][gte]=30&filter[name]=amy,jessica", nil)
+
+	conditions, err := FromJSONAPI(request.URL.Query())
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectG
+	err = db.Scopes(Scope(conditions...)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectG{{Name: "amy", Age: 30}}, actual)
+}
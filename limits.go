@@ -0,0 +1,86 @@
+package gormqonvert
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// conditionCountKey is used to keep track of the number of converted conditions in a statement
+const conditionCountKey = tagName + ":conditions"
+
+// Limits restricts how much of a query is converted, to protect the database against filters from untrusted input.
+// Limits that are 0 are not checked.
+type Limits struct {
+	// Conditions is the maximum number of converted conditions in a query
+	Conditions int
+
+	// Values is the maximum number of values of a column, like the members of an IN-query
+	Values int
+
+	// PatternLength is the maximum length of LIKE- and regex-patterns
+	PatternLength int
+
+	// Depth is the maximum number of nested AND- and OR-groups in a query, including $or- and $and-documents
+	Depth int
+}
+
+// LimitError is added to a query if it exceeds one of the limits given to WithLimits(), it can be found using
+// errors.As().
+type LimitError struct {
+	// Limit is the name of the limit, like 'conditions' or 'pattern length'
+	Limit string
+
+	// Maximum is the configured limit
+	Maximum int
+
+	// Actual is the value that exceeded it
+	Actual int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s of %d exceeds the limit of %d", e.Limit, e.Actual, e.Maximum)
+}
+
+// checkLimit adds a LimitError to the query if the value exceeds the maximum, the result is false in that case
+func checkLimit(db *gorm.DB, limit string, maximum int, actual int) bool {
+	if maximum == 0 || actual <= maximum {
+		return true
+	}
+
+	_ = db.AddError(&LimitError{Limit: limit, Maximum: maximum, Actual: actual})
+
+	return false
+}
+
+// checkLimits counts the condition and checks if it exceeds any of the limits, the result is false if it does
+func (d *gormQonvert) checkLimits(db *gorm.DB, condition Condition) bool {
+	count := 1
+	if value, ok := db.InstanceGet(conditionCountKey); ok {
+		count += value.(int)
+	}
+
+	db.InstanceSet(conditionCountKey, count)
+
+	// Only the first condition over the limit is reported
+	if d.limits.Conditions > 0 && count > d.limits.Conditions+1 {
+		return false
+	}
+
+	if !checkLimit(db, "conditions", d.limits.Conditions, count) {
+		return false
+	}
+
+	if condition.Operator == OperatorIn || condition.Operator == OperatorNotIn {
+		if !checkLimit(db, "values", d.limits.Values, len(toList(condition.Value))) {
+			return false
+		}
+	}
+
+	switch condition.Operator {
+	case OperatorLike, OperatorNotLike, OperatorContains, OperatorStartsWith, OperatorEndsWith, OperatorRegex:
+		return checkLimit(db, "pattern length", d.limits.PatternLength, len(fmt.Sprint(condition.Value)))
+	}
+
+	return true
+}
@@ -0,0 +1,157 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGormQonvert_WithLimits_AddsLimitError(t *testing.T) {
+	t.Parallel()
+
+	type ObjectH struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		limits   Limits
+		query    func(*gorm.DB) *gorm.DB
+		expected error
+	}{
+		"no limits": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": []string{"~a%", "~b%", "~c%"}, "age": ">3"})
+			},
+		},
+		"within limits": {
+			limits: Limits{Conditions: 4, Values: 3, PatternLength: 2, Depth: 1},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": []string{"~a%", "~b%", "~c%"}, "age": ">3"})
+			},
+		},
+		"too many conditions": {
+			limits: Limits{Conditions: 3},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": []string{"~a%", "~b%", "~c%"}, "age": ">3"})
+			},
+			expected: &LimitError{Limit: "conditions", Maximum: 3, Actual: 4},
+		},
+		"unconverted conditions are not counted": {
+			limits: Limits{Conditions: 1},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": "jessica", "age": ">3"})
+			},
+		},
+		"too many values": {
+			limits: Limits{Values: 2},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": []string{"~a%", "~b%", "~c%"}})
+			},
+			expected: &LimitError{Limit: "values", Maximum: 2, Actual: 3},
+		},
+		"too many values in key": {
+			limits: Limits{Values: 2},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"age[in]": "1,2,3"})
+			},
+			expected: &LimitError{Limit: "values", Maximum: 2, Actual: 3},
+		},
+		"pattern too long": {
+			limits: Limits{PatternLength: 3},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": "~jes%"})
+			},
+			expected: &LimitError{Limit: "pattern length", Maximum: 3, Actual: 4},
+		},
+		"too deep": {
+			limits: Limits{Depth: 1},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(db.Where(map[string]any{"age": ">3"}).Or(db.Where(map[string]any{"name": "~j%"}).Or(map[string]any{"name": "~a%"})))
+			},
+			expected: &LimitError{Limit: "depth", Maximum: 1, Actual: 2},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{LikePrefix: "~", GreaterThanPrefix: ">"}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectH{})
+			_ = db.Use(New(config, BracketKeys(), WithLimits(testData.limits)))
+
+			// Act
+			err := testData.query(db).Find(&[]ObjectH{}).Error
+
+			// Assert
+			assert.Equal(t, testData.expected, err)
+		})
+	}
+}
+
+func TestGormQonvert_WithLimits_CountsDepthOfMongoDocuments(t *testing.T) {
+	t.Parallel()
+
+	type ObjectS struct {
+		Name string
+		Age  int
+	}
+
+	// nested creates a $or-document that is nested the given number of times
+	nested := func(levels int) map[string]any {
+		document := map[string]any{"age": 3}
+		for level := 0; level < levels; level++ {
+			document = map[string]any{"$or": []map[string]any{document, {"name": "jessica"}}}
+		}
+
+		return document
+	}
+
+	tests := map[string]struct {
+		limits   Limits
+		filter   map[string]any
+		expected error
+	}{
+		"within limits": {
+			limits: Limits{Depth: 2},
+			filter: nested(2),
+		},
+		"too deep": {
+			limits:   Limits{Depth: 1},
+			filter:   nested(50),
+			expected: &LimitError{Limit: "depth", Maximum: 1, Actual: 50},
+		},
+		"operators are not groups": {
+			limits: Limits{Depth: 1},
+			filter: map[string]any{"age": map[string]any{"$gte": 3, "$lt": 5}},
+		},
+		"depth of gorm groups is added": {
+			limits:   Limits{Depth: 2},
+			filter:   map[string]any{"name": "jessica", "$and": []map[string]any{nested(1)}},
+			expected: &LimitError{Limit: "depth", Maximum: 2, Actual: 3},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectS{})
+			_ = db.Use(New(CharacterConfig{}, MongoOperators(), WithLimits(testData.limits)))
+
+			// Act
+			err := db.Where(testData.filter).Find(&[]ObjectS{}).Error
+
+			// Assert
+			assert.Equal(t, testData.expected, err)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mongoOperators are the operators that can be used in documents like {"age": {"$gte": 30}}
+var mongoOperators = map[string]Operator{
+	"$eq":    OperatorEqual,
+	"$ne":    OperatorNotEqual,
+	"$gt":    OperatorGreaterThan,
+	"$gte":   OperatorGreaterOrEqualTo,
+	"$lt":    OperatorLessThan,
+	"$lte":   OperatorLessOrEqualTo,
+	"$in":    OperatorIn,
+	"$nin":   OperatorNotIn,
+	"$regex": OperatorRegex,
+}
+
+const (
+	mongoOr     = "$or"
+	mongoAnd    = "$and"
+	mongoExists = "$exists"
+)
+
+// ParseMongo turns a MongoDB-style filter document like {"age": {"$gte": 30, "$lt": 40}} into conditions. Values
+// keep their type, plain values are equal-checks and lists become IN-checks, like they would in gorm.
+// The supported operators are $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $regex, $exists, $or and $and.
+func ParseMongo(document map[string]any) ([]Condition, error) {
+	keys := sortedKeys(document)
+
+	result := make([]Condition, 0, len(keys))
+
+	for _, key := range keys {
+		condition, err := mongoCondition(key, document[key])
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, condition)
+	}
+
+	return result, nil
+}
+
+// mongoCondition converts a single key of a document and its value into a condition
+func mongoCondition(key string, value any) (Condition, error) {
+	if key == mongoOr || key == mongoAnd {
+		return mongoGroup(key, value)
+	}
+
+	if strings.HasPrefix(key, "$") {
+		return Condition{}, fmt.Errorf("unknown operator '%s'", key)
+	}
+
+	operators, ok := value.(map[string]any)
+	if !ok {
+		if isSlice(value) {
+			return Condition{Column: key, Operator: OperatorIn, Value: toList(value)}, nil
+		}
+
+		return Condition{Column: key, Operator: OperatorEqual, Value: value}, nil
+	}
+
+	if len(operators) == 0 {
+		return Condition{}, fmt.Errorf("no operators given for '%s'", key)
+	}
+
+	operatorKeys := sortedKeys(operators)
+
+	conditions := make([]Condition, 0, len(operatorKeys))
+
+	for _, operatorKey := range operatorKeys {
+		operatorValue := operators[operatorKey]
+
+		if operatorKey == mongoExists {
+			exists, ok := operatorValue.(bool)
+			if !ok {
+				return Condition{}, fmt.Errorf("'%s' of '%s' must be a bool", operatorKey, key)
+			}
+
+			conditions = append(conditions, Condition{Column: key, Operator: OperatorIsNull, Value: !exists})
+			continue
+		}
+
+		operator, ok := mongoOperators[operatorKey]
+		if !ok {
+			return Condition{}, fmt.Errorf("unknown operator '%s' for '%s'", operatorKey, key)
+		}
+
+		if operator == OperatorIn || operator == OperatorNotIn {
+			if !isSlice(operatorValue) {
+				return Condition{}, fmt.Errorf("'%s' of '%s' must be a list", operatorKey, key)
+			}
+
+			operatorValue = toList(operatorValue)
+		}
+
+		conditions = append(conditions, Condition{Column: key, Operator: operator, Value: operatorValue})
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+
+	return And(conditions...), nil
+}
+
+// mongoDepth returns the number of nested $or- and $and-groups in the value of a key of a document
+func mongoDepth(key string, value any) int {
+	if (key != mongoOr && key != mongoAnd) || !isSlice(value) {
+		return 0
+	}
+
+	var deepest int
+
+	for _, document := range toList(value) {
+		document, _ := document.(map[string]any)
+		for documentKey, documentValue := range document {
+			deepest = max(deepest, mongoDepth(documentKey, documentValue))
+		}
+	}
+
+	return deepest + 1
+}
+
+// mongoGroup converts the list of documents of $or and $and into a group
+func mongoGroup(key string, value any) (Condition, error) {
+	if !isSlice(value) || len(toList(value)) == 0 {
+		return Condition{}, fmt.Errorf("'%s' must be a list of documents", key)
+	}
+
+	documents := toList(value)
+	groups := make([]Condition, len(documents))
+
+	for index, document := range documents {
+		document, ok := document.(map[string]any)
+		if !ok || len(document) == 0 {
+			return Condition{}, fmt.Errorf("'%s' must be a list of documents", key)
+		}
+
+		conditions, err := ParseMongo(document)
+		if err != nil {
+			return Condition{}, err
+		}
+
+		groups[index] = conditions[0]
+		if len(conditions) > 1 {
+			groups[index] = And(conditions...)
+		}
+	}
+
+	if key == mongoOr {
+		return Or(groups...), nil
+	}
+
+	return And(groups...), nil
+}
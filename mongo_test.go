@@ -0,0 +1,201 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestParseMongo_ReturnsExpectedConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		document map[string]any
+		expected []Condition
+	}{
+		"nothing": {
+			document: map[string]any{},
+			expected: []Condition{},
+		},
+		"plain values": {
+			document: map[string]any{"name": "jessica", "age": []int{30, 31}},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorIn, Value: []any{30, 31}},
+				{Column: "name", Operator: OperatorEqual, Value: "jessica"},
+			},
+		},
+		"single operator": {
+			document: map[string]any{"age": map[string]any{"$ne": 30}},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorNotEqual, Value: 30},
+			},
+		},
+		"multiple operators": {
+			document: map[string]any{"age": map[string]any{"$gte": 30, "$lt": 40.5}},
+			expected: []Condition{
+				And(
+					Condition{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: 30},
+					Condition{Column: "age", Operator: OperatorLessThan, Value: 40.5},
+				),
+			},
+		},
+		"list operators": {
+			document: map[string]any{"age": map[string]any{"$in": []int{30}, "$nin": []any{31, 32}}},
+			expected: []Condition{
+				And(
+					Condition{Column: "age", Operator: OperatorIn, Value: []any{30}},
+					Condition{Column: "age", Operator: OperatorNotIn, Value: []any{31, 32}},
+				),
+			},
+		},
+		"exists": {
+			document: map[string]any{"name": map[string]any{"$exists": true, "$regex": "^j"}},
+			expected: []Condition{
+				And(
+					Condition{Column: "name", Operator: OperatorIsNull, Value: false},
+					Condition{Column: "name", Operator: OperatorRegex, Value: "^j"},
+				),
+			},
+		},
+		"or": {
+			document: map[string]any{
+				"$or": []map[string]any{
+					{"age": map[string]any{"$lt": 30}},
+					{"name": "amy", "age": 30},
+				},
+			},
+			expected: []Condition{
+				Or(
+					Condition{Column: "age", Operator: OperatorLessThan, Value: 30},
+					And(
+						Condition{Column: "age", Operator: OperatorEqual, Value: 30},
+						Condition{Column: "name", Operator: OperatorEqual, Value: "amy"},
+					),
+				),
+			},
+		},
+		"and": {
+			document: map[string]any{
+				"$and": []any{
+					map[string]any{"age": map[string]any{"$gt": 30}},
+					map[string]any{"age": map[string]any{"$lt": 40}},
+				},
+			},
+			expected: []Condition{
+				And(
+					Condition{Column: "age", Operator: OperatorGreaterThan, Value: 30},
+					Condition{Column: "age", Operator: OperatorLessThan, Value: 40},
+				),
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := ParseMongo(testData.document)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParseMongo_ReturnsErrorOnInvalidDocument(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		document map[string]any
+		expected string
+	}{
+		"unknown top-level operator": {
+			document: map[string]any{"$nor": []any{}},
+			expected: "unknown operator '$nor'",
+		},
+		"unknown operator": {
+			document: map[string]any{"age": map[string]any{"$between": 1}},
+			expected: "unknown operator '$between' for 'age'",
+		},
+		"no operators": {
+			document: map[string]any{"age": map[string]any{}},
+			expected: "no operators given for 'age'",
+		},
+		"in without list": {
+			document: map[string]any{"age": map[string]any{"$in": 30}},
+			expected: "'$in' of 'age' must be a list",
+		},
+		"exists without bool": {
+			document: map[string]any{"age": map[string]any{"$exists": "yes"}},
+			expected: "'$exists' of 'age' must be a bool",
+		},
+		"or without list": {
+			document: map[string]any{"$or": map[string]any{"age": 30}},
+			expected: "'$or' must be a list of documents",
+		},
+		"or without documents": {
+			document: map[string]any{"$or": []any{30}},
+			expected: "'$or' must be a list of documents",
+		},
+		"nested error": {
+			document: map[string]any{"$and": []any{map[string]any{"age": map[string]any{"$foo": 1}}}},
+			expected: "unknown operator '$foo' for 'age'",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := ParseMongo(testData.document)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.EqualError(t, err, testData.expected)
+		})
+	}
+}
+
+func TestGormQonvert_MongoOperators_ConvertsRegex(t *testing.T) {
+	t.Parallel()
+
+	type ObjectE struct {
+		Name string
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.Use(New(CharacterConfig{}, MongoOperators()))
+
+	// Act
+	result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(map[string]any{"name": map[string]any{"$regex": "^j"}}).Find(&[]ObjectE{})
+	})
+
+	// Assert
+	assert.Equal(t, "SELECT * FROM `object_es` WHERE `object_es`.`name` REGEXP \"^j\"", result)
+}
+
+func TestGormQonvert_MongoOperators_AddsErrorOnInvalidDocument(t *testing.T) {
+	t.Parallel()
+
+	type ObjectE struct {
+		Name string
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectE{})
+	_ = db.Use(New(CharacterConfig{}, MongoOperators()))
+
+	// Act
+	err := db.Where(map[string]any{"name": map[string]any{"$foo": "bar"}}).Find(&[]ObjectE{}).Error
+
+	// Assert
+	assert.EqualError(t, err, "unknown operator '$foo' for 'name'")
+}
@@ -0,0 +1,179 @@
+package gormqonvert
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ErrOutOfRange is added to a query if WithNumberFormat() is used and a number does not fit the size of its field
+var ErrOutOfRange = errors.New("value out of range")
+
+// NumberFormat describes how numbers are written in values of numeric fields, see WithNumberFormat()
+type NumberFormat struct {
+	// ThousandsSeparator is removed from numbers, like ',' in '1,000' or '.' in '1.000'
+	ThousandsSeparator string
+
+	// DecimalMark separates the fraction from the rest of the number, like ',' in '2,5'. It's '.' if empty.
+	DecimalMark string
+
+	// Units are suffixes that multiply the number, like {"k": 1000, "MB": 1000000} for '5k' or '2.5MB'
+	Units map[string]float64
+}
+
+// resolveNumbers replaces numbers in the value of a condition on a numeric field if WithNumberFormat() is used,
+// integer fields get an int64 or uint64 and other fields a float64. The result is not ok if nothing was replaced,
+// the error is a *FilterError if a number does not fit its field.
+func (d *gormQonvert) resolveNumbers(db *gorm.DB, condition Condition) (Condition, bool, error) {
+	if d.numberFormat == nil || db.Statement.Schema == nil || patternOperators[condition.Operator] || condition.Operator == OperatorIsNull {
+		return condition, false, nil
+	}
+
+	field, _ := d.lookUpField(db.Statement.Schema, condition.Column)
+	if field == nil || (field.DataType != schema.Int && field.DataType != schema.Uint && field.DataType != schema.Float) {
+		return condition, false, nil
+	}
+
+	var resolved bool
+
+	values := toList(condition.Value)
+	for index, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		number, ok := d.numberFormat.parse(text)
+		if !ok {
+			continue
+		}
+
+		converted, err := fitNumber(field, number)
+		if err != nil {
+			return condition, false, &FilterError{Err: err, Column: condition.Column, Value: text, Operator: condition.Operator}
+		}
+
+		values[index] = converted
+		resolved = true
+	}
+
+	if isSlice(condition.Value) {
+		condition.Value = values
+	} else {
+		condition.Value = values[0]
+	}
+
+	return condition, resolved, nil
+}
+
+// parse turns the text into a number, the result is not ok if the text isn't a number in this format
+func (f *NumberFormat) parse(text string) (*big.Rat, bool) {
+	text = strings.TrimSpace(text)
+
+	// The longest unit wins, so 'MB' is not mistaken for 'B'
+	var unit string
+	for name := range f.Units {
+		if len(name) > len(unit) && strings.HasSuffix(text, name) {
+			unit = name
+		}
+	}
+
+	text = strings.TrimSpace(strings.TrimSuffix(text, unit))
+
+	if f.ThousandsSeparator != "" {
+		text = strings.ReplaceAll(text, f.ThousandsSeparator, "")
+	}
+
+	if f.DecimalMark != "" && f.DecimalMark != "." {
+		text = strings.Replace(text, f.DecimalMark, ".", 1)
+	}
+
+	if !isDecimal(text) {
+		return nil, false
+	}
+
+	number, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, false
+	}
+
+	if unit != "" {
+		factor := new(big.Rat).SetFloat64(f.Units[unit])
+		if factor == nil {
+			return nil, false
+		}
+
+		number.Mul(number, factor)
+	}
+
+	return number, true
+}
+
+// isDecimal returns true if the text is a number like '-12' or '3.5', without exponents or other notations
+func isDecimal(text string) bool {
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	var digits int
+
+	for index, character := range text {
+		switch {
+		case character >= '0' && character <= '9':
+			digits++
+		case character != '.' || strings.Contains(text[index+1:], "."):
+			return false
+		}
+	}
+
+	return digits > 0
+}
+
+// fitNumber converts the number to the type of the field, the error is ErrOutOfRange if it doesn't fit the size of
+// the field and ErrInvalidValue if an integer field gets a fraction
+func fitNumber(field *schema.Field, number *big.Rat) (any, error) {
+	size := field.Size
+	if size <= 0 || size > 64 {
+		size = 64
+	}
+
+	if field.DataType == schema.Float {
+		value, _ := number.Float64()
+
+		if math.IsInf(value, 0) || (size == 32 && math.Abs(value) > math.MaxFloat32) {
+			return nil, ErrOutOfRange
+		}
+
+		return value, nil
+	}
+
+	if !number.IsInt() {
+		return nil, ErrInvalidValue
+	}
+
+	integer := number.Num()
+
+	if field.DataType == schema.Uint {
+		if integer.Sign() < 0 || integer.BitLen() > size {
+			return nil, ErrOutOfRange
+		}
+
+		return integer.Uint64(), nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+	if integer.Cmp(limit) >= 0 || integer.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, ErrOutOfRange
+	}
+
+	return integer.Int64(), nil
+}
+
+// isNumber returns true if the value is a number that is resolved by WithNumberFormat(), these are always converted
+func (d *gormQonvert) isNumber(db *gorm.DB, column string, value any) bool {
+	_, ok, err := d.resolveNumbers(db, Condition{Column: column, Operator: OperatorEqual, Value: value})
+
+	return ok || err != nil
+}
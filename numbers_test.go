@@ -0,0 +1,137 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGormQonvert_WithNumberFormat_ParsesNumbers(t *testing.T) {
+	t.Parallel()
+
+	type ObjectO struct {
+		Name  string
+		Age   int8
+		Views uint
+		Size  float64
+	}
+
+	european := NumberFormat{ThousandsSeparator: ".", DecimalMark: ","}
+	units := NumberFormat{ThousandsSeparator: ",", Units: map[string]float64{"k": 1000, "B": 1, "MB": 1000000}}
+
+	tests := map[string]struct {
+		format   NumberFormat
+		filter   map[string]any
+		expected string
+		error    error
+	}{
+		"plain number": {
+			filter:   map[string]any{"views": ">=5"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`views` >= 5",
+		},
+		"thousands separator": {
+			format:   units,
+			filter:   map[string]any{"views": ">=1,000,000"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`views` >= 1000000",
+		},
+		"european format": {
+			format:   european,
+			filter:   map[string]any{"size": "<1.000,5"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`size` < 1000.5",
+		},
+		"unit": {
+			format:   units,
+			filter:   map[string]any{"views": ">=5k"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`views` >= 5000",
+		},
+		"longest unit": {
+			format:   units,
+			filter:   map[string]any{"size": "<2.5MB"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`size` < 2500000",
+		},
+		"without prefix": {
+			format:   units,
+			filter:   map[string]any{"views": "2k"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`views` = 2000",
+		},
+		"list of numbers": {
+			format:   units,
+			filter:   map[string]any{"views": []string{"1k", ">10k"}},
+			expected: "SELECT * FROM `object_os` WHERE (`object_os`.`views` = 1000 OR `object_os`.`views` > 10000)",
+		},
+		"list without prefixes": {
+			format:   NumberFormat{ThousandsSeparator: ".", Units: map[string]float64{"k": 1000}},
+			filter:   map[string]any{"views": []string{"1.000", "2k"}},
+			expected: "SELECT * FROM `object_os` WHERE (`object_os`.`views` = 1000 OR `object_os`.`views` = 2000)",
+		},
+		"not a number": {
+			format:   units,
+			filter:   map[string]any{"views": ">=lots"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`views` >= \"lots\"",
+		},
+		"exponents are not numbers": {
+			filter:   map[string]any{"views": ">=1e3"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`views` >= \"1e3\"",
+		},
+		"text field": {
+			format:   units,
+			filter:   map[string]any{"name": "5k"},
+			expected: "SELECT * FROM `object_os` WHERE `object_os`.`name` = \"5k\"",
+		},
+		"too large for the field": {
+			filter: map[string]any{"age": ">=128"},
+			error:  ErrOutOfRange,
+		},
+		"too small for the field": {
+			filter: map[string]any{"age": ">=-129"},
+			error:  ErrOutOfRange,
+		},
+		"negative unsigned": {
+			filter: map[string]any{"views": ">=-1"},
+			error:  ErrOutOfRange,
+		},
+		"fraction of an integer": {
+			format: european,
+			filter: map[string]any{"age": ">=2,5"},
+			error:  ErrInvalidValue,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{
+				GreaterThanPrefix:      ">",
+				GreaterOrEqualToPrefix: ">=",
+				LessThanPrefix:         "<",
+			}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, WithNumberFormat(testData.format)))
+
+			// Act
+			query := db.Session(&gorm.Session{DryRun: true}).Where(testData.filter).Find(&[]ObjectO{})
+
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(&[]ObjectO{})
+			})
+
+			// Assert
+			if testData.error != nil {
+				assert.ErrorIs(t, query.Error, testData.error)
+
+				var filterError *FilterError
+				assert.ErrorAs(t, query.Error, &filterError)
+
+				return
+			}
+
+			assert.NoError(t, query.Error)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package odata
+
+import (
+	"strings"
+
+	"github.com/survivorbat/gorm-query-convert/internal/lexer"
+)
+
+// tokenize splits the filter into tokens, the last token is always an end token
+func tokenize(filter string) ([]lexer.Token, error) {
+	return lexer.Tokenize(filter, scan)
+}
+
+// scan reads the comma, string, number or identifier at the position
+func scan(filter string, position int) (lexer.Token, int, error) {
+	character := filter[position]
+
+	switch {
+	case character == ',':
+		return lexer.Token{Kind: lexer.Comma, Text: ",", Position: position}, position + 1, nil
+
+	case character == '\'':
+		text, end, ok := readString(filter, position)
+		if !ok {
+			return lexer.Token{}, 0, lexer.Errorf(position, "unterminated string")
+		}
+
+		return lexer.Token{Kind: lexer.String, Text: text, Position: position}, end, nil
+
+	case character == '-' || isDigit(character):
+		end := position + 1
+		for end < len(filter) && (isDigit(filter[end]) || filter[end] == '.') {
+			end++
+		}
+
+		return lexer.Token{Kind: lexer.Number, Text: filter[position:end], Position: position}, end, nil
+
+	case isIdentifierCharacter(character):
+		end := position
+		for end < len(filter) && (isIdentifierCharacter(filter[end]) || isDigit(filter[end])) {
+			end++
+		}
+
+		return lexer.Token{Kind: lexer.Text, Text: filter[position:end], Position: position}, end, nil
+	}
+
+	return lexer.Token{}, 0, lexer.Errorf(position, "unexpected '%c'", character)
+}
+
+// readString reads a single-quoted string starting at the given position, quotes are escaped by doubling them.
+// It returns the position after the closing quote.
+func readString(filter string, position int) (string, int, bool) {
+	var result strings.Builder
+
+	for position++; position < len(filter); position++ {
+		if filter[position] != '\'' {
+			result.WriteByte(filter[position])
+			continue
+		}
+
+		if position+1 < len(filter) && filter[position+1] == '\'' {
+			result.WriteByte('\'')
+			position++
+			continue
+		}
+
+		return result.String(), position + 1, true
+	}
+
+	return "", 0, false
+}
+
+func isDigit(character byte) bool {
+	return character >= '0' && character <= '9'
+}
+
+func isIdentifierCharacter(character byte) bool {
+	return character == '_' || character == '/' || (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
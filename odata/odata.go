@@ -0,0 +1,314 @@
+// Package odata parses a subset of OData $filter expressions, like "Age ge 30 and startswith(Name,'Jes')", into
+// gormqonvert conditions.
+//
+// The supported comparison operators are eq, ne, gt, ge, lt and le, which can be combined using and, or, not and
+// parentheses. The string functions contains, startswith and endswith can be used as conditions and tolower around
+// a property makes its comparison case-insensitive. Properties are converted to column names using gorm's default
+// naming strategy, so 'CreatedAt' filters on 'created_at'. Comparing to null checks for IS (NOT) NULL.
+//
+// Filters from users should be parsed with AllowedProperties(), since the conditions are added to queries by
+// gormqonvert.Scope(), which skips the limits and other checks of the plugin.
+package odata
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+	"github.com/survivorbat/gorm-query-convert/internal/lexer"
+	"gorm.io/gorm/schema"
+)
+
+// comparisonOperators maps the OData comparison operators to the operators of the plugin
+var comparisonOperators = map[string]gormqonvert.Operator{
+	"eq": gormqonvert.OperatorEqual,
+	"ne": gormqonvert.OperatorNotEqual,
+	"gt": gormqonvert.OperatorGreaterThan,
+	"ge": gormqonvert.OperatorGreaterOrEqualTo,
+	"lt": gormqonvert.OperatorLessThan,
+	"le": gormqonvert.OperatorLessOrEqualTo,
+}
+
+// functionOperators maps the OData string functions to the operators of the plugin
+var functionOperators = map[string]gormqonvert.Operator{
+	"contains":   gormqonvert.OperatorContains,
+	"startswith": gormqonvert.OperatorStartsWith,
+	"endswith":   gormqonvert.OperatorEndsWith,
+}
+
+// namingStrategy converts property names to column names
+var namingStrategy = schema.NamingStrategy{}
+
+// ErrUnknownProperty is returned if a property in the filter is not one of the AllowedProperties()
+var ErrUnknownProperty = errors.New("unknown property")
+
+// Option changes how filters are parsed
+type Option func(*parser)
+
+// AllowedProperties makes it so that only the given properties, like 'CreatedAt', can be used in the filter. Other
+// properties result in an ErrUnknownProperty. All properties are allowed if this option is not used.
+func AllowedProperties(properties ...string) Option {
+	return func(p *parser) {
+		p.allowedProperties = make(map[string]struct{}, len(properties))
+		for _, property := range properties {
+			p.allowedProperties[property] = struct{}{}
+		}
+	}
+}
+
+// SyntaxError is returned if a filter could not be parsed, Position is the byte offset in the filter at which
+// the problem was found.
+type SyntaxError = lexer.SyntaxError
+
+// Parse turns a $filter expression into a single condition, that can be added to a query
+// using db.Scopes(gormqonvert.Scope(condition)).
+func Parse(filter string, opts ...Option) (gormqonvert.Condition, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	p := &parser{Cursor: lexer.Cursor{Tokens: tokens}}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	condition, err := p.parseOr()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	if next := p.Peek(); next.Kind != lexer.End {
+		return gormqonvert.Condition{}, lexer.Errorf(next.Position, "unexpected '%s'", next.Text)
+	}
+
+	return condition, nil
+}
+
+type parser struct {
+	lexer.Cursor
+
+	// allowedProperties are the properties that can be used, nil if all of them can
+	allowedProperties map[string]struct{}
+}
+
+// parseOr parses expressions separated by 'or'
+func (p *parser) parseOr() (gormqonvert.Condition, error) {
+	return p.parseList("or", p.parseAnd, gormqonvert.Or)
+}
+
+// parseAnd parses expressions separated by 'and', which takes precedence over 'or'
+func (p *parser) parseAnd() (gormqonvert.Condition, error) {
+	return p.parseList("and", p.parseUnary, gormqonvert.And)
+}
+
+// parseList parses one or more elements separated by the keyword and combines them if there are multiple
+func (p *parser) parseList(keyword string, element func() (gormqonvert.Condition, error), combine func(...gormqonvert.Condition) gormqonvert.Condition) (gormqonvert.Condition, error) {
+	first, err := element()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	conditions := []gormqonvert.Condition{first}
+
+	for p.Peek().Is(keyword) {
+		p.Position++
+
+		next, err := element()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		conditions = append(conditions, next)
+	}
+
+	if len(conditions) == 1 {
+		return first, nil
+	}
+
+	return combine(conditions...), nil
+}
+
+// parseUnary parses an optionally negated expression
+func (p *parser) parseUnary() (gormqonvert.Condition, error) {
+	if !p.Peek().Is("not") {
+		return p.parsePrimary()
+	}
+
+	p.Position++
+
+	condition, err := p.parseUnary()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	return gormqonvert.Not(condition), nil
+}
+
+// parsePrimary parses an expression between parentheses, a string function or a comparison
+func (p *parser) parsePrimary() (gormqonvert.Condition, error) {
+	current := p.Peek()
+
+	if current.Kind == lexer.LeftParenthesis {
+		p.Position++
+
+		condition, err := p.parseOr()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		if err := p.Expect(lexer.RightParenthesis, "')'"); err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		return condition, nil
+	}
+
+	if operator, ok := functionOperators[current.Text]; ok && current.Kind == lexer.Text {
+		return p.parseFunction(operator)
+	}
+
+	condition, err := p.parseProperty()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	operatorToken := p.Next()
+
+	operator, ok := comparisonOperators[operatorToken.Text]
+	if !ok || operatorToken.Kind != lexer.Text {
+		return gormqonvert.Condition{}, lexer.Errorf(operatorToken.Position, "expected comparison operator")
+	}
+
+	literalToken := p.Peek()
+
+	value, err := p.parseLiteral()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	if value != nil {
+		condition.Operator = operator
+		condition.Value = value
+
+		return condition, nil
+	}
+
+	switch operator {
+	case gormqonvert.OperatorEqual:
+		condition.Value = true
+	case gormqonvert.OperatorNotEqual:
+		condition.Value = false
+	default:
+		return gormqonvert.Condition{}, lexer.Errorf(literalToken.Position, "null can only be compared using eq or ne")
+	}
+
+	condition.Operator = gormqonvert.OperatorIsNull
+	condition.IgnoreCase = false
+
+	return condition, nil
+}
+
+// parseFunction parses the arguments of a string function like contains(Name,'jes')
+func (p *parser) parseFunction(operator gormqonvert.Operator) (gormqonvert.Condition, error) {
+	p.Position++
+
+	if err := p.Expect(lexer.LeftParenthesis, "'('"); err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	condition, err := p.parseProperty()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	if err := p.Expect(lexer.Comma, "','"); err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	argument := p.Next()
+	if argument.Kind != lexer.String {
+		return gormqonvert.Condition{}, lexer.Errorf(argument.Position, "expected string")
+	}
+
+	if err := p.Expect(lexer.RightParenthesis, "')'"); err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	condition.Operator = operator
+	condition.Value = argument.Text
+
+	return condition, nil
+}
+
+// parseProperty parses a property, optionally wrapped in tolower(), into a condition without operator and value
+func (p *parser) parseProperty() (gormqonvert.Condition, error) {
+	current := p.Next()
+
+	if current.Is("tolower") {
+		if err := p.Expect(lexer.LeftParenthesis, "'('"); err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		condition, err := p.parseProperty()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		if err := p.Expect(lexer.RightParenthesis, "')'"); err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		condition.IgnoreCase = true
+
+		return condition, nil
+	}
+
+	if current.Kind != lexer.Text {
+		return gormqonvert.Condition{}, lexer.Errorf(current.Position, "expected property")
+	}
+
+	if strings.Contains(current.Text, "/") {
+		return gormqonvert.Condition{}, lexer.Errorf(current.Position, "navigation properties are not supported")
+	}
+
+	if _, ok := p.allowedProperties[current.Text]; p.allowedProperties != nil && !ok {
+		return gormqonvert.Condition{}, fmt.Errorf("%w '%s' at position %d", ErrUnknownProperty, current.Text, current.Position)
+	}
+
+	return gormqonvert.Condition{Column: namingStrategy.ColumnName("", current.Text)}, nil
+}
+
+// parseLiteral parses a string, number, boolean or null, the latter results in a nil value
+func (p *parser) parseLiteral() (any, error) {
+	current := p.Next()
+
+	switch {
+	case current.Kind == lexer.String:
+		return current.Text, nil
+
+	case current.Kind == lexer.Number:
+		if !strings.Contains(current.Text, ".") {
+			if value, err := strconv.ParseInt(current.Text, 10, 64); err == nil {
+				return value, nil
+			}
+		}
+
+		value, err := strconv.ParseFloat(current.Text, 64)
+		if err != nil {
+			return nil, lexer.Errorf(current.Position, "invalid number '%s'", current.Text)
+		}
+
+		return value, nil
+
+	case current.Is("true"), current.Is("false"):
+		return current.Text == "true", nil
+
+	case current.Is("null"):
+		return nil, nil
+	}
+
+	return nil, lexer.Errorf(current.Position, "expected literal")
+}
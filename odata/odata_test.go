@@ -0,0 +1,229 @@
+package odata
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+)
+
+func TestParse_ReturnsExpectedCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   string
+		expected gormqonvert.Condition
+	}{
+		"comparison": {
+			filter:   "Age ge 30",
+			expected: gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterOrEqualTo, Value: int64(30)},
+		},
+		"negative float": {
+			filter:   "Score lt -2.5",
+			expected: gormqonvert.Condition{Column: "score", Operator: gormqonvert.OperatorLessThan, Value: -2.5},
+		},
+		"string with quote": {
+			filter:   "LastName eq 'O''Brien'",
+			expected: gormqonvert.Condition{Column: "last_name", Operator: gormqonvert.OperatorEqual, Value: "O'Brien"},
+		},
+		"boolean": {
+			filter:   "Archived ne true",
+			expected: gormqonvert.Condition{Column: "archived", Operator: gormqonvert.OperatorNotEqual, Value: true},
+		},
+		"null": {
+			filter:   "DeletedAt eq null",
+			expected: gormqonvert.Condition{Column: "deleted_at", Operator: gormqonvert.OperatorIsNull, Value: true},
+		},
+		"not null": {
+			filter:   "DeletedAt ne null",
+			expected: gormqonvert.Condition{Column: "deleted_at", Operator: gormqonvert.OperatorIsNull, Value: false},
+		},
+		"tolower": {
+			filter:   "tolower(Name) eq 'jessica'",
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "jessica", IgnoreCase: true},
+		},
+		"functions": {
+			filter: "contains(Name,'ss') or startswith(tolower(Name), 'jes') or endswith(Name,'ca')",
+			expected: gormqonvert.Or(
+				gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorContains, Value: "ss"},
+				gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorStartsWith, Value: "jes", IgnoreCase: true},
+				gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEndsWith, Value: "ca"},
+			),
+		},
+		"and takes precedence": {
+			filter: "Age lt 30 or Age gt 40 and not (Name eq 'amy')",
+			expected: gormqonvert.Or(
+				gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorLessThan, Value: int64(30)},
+				gormqonvert.And(
+					gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterThan, Value: int64(40)},
+					gormqonvert.Not(gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "amy"}),
+				),
+			),
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := Parse(testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParse_ReturnsSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   string
+		expected *SyntaxError
+	}{
+		"empty": {
+			filter:   "",
+			expected: &SyntaxError{Position: 0, Message: "expected property"},
+		},
+		"unknown operator": {
+			filter:   "Age between 30",
+			expected: &SyntaxError{Position: 4, Message: "expected comparison operator"},
+		},
+		"missing literal": {
+			filter:   "Age ge",
+			expected: &SyntaxError{Position: 6, Message: "expected literal"},
+		},
+		"invalid number": {
+			filter:   "Age ge 1.2.3",
+			expected: &SyntaxError{Position: 7, Message: "invalid number '1.2.3'"},
+		},
+		"null with other operator": {
+			filter:   "Age ge null",
+			expected: &SyntaxError{Position: 7, Message: "null can only be compared using eq or ne"},
+		},
+		"unterminated string": {
+			filter:   "Name eq 'amy",
+			expected: &SyntaxError{Position: 8, Message: "unterminated string"},
+		},
+		"function without string": {
+			filter:   "contains(Name, 3)",
+			expected: &SyntaxError{Position: 15, Message: "expected string"},
+		},
+		"function without parentheses": {
+			filter:   "contains Name",
+			expected: &SyntaxError{Position: 9, Message: "expected '('"},
+		},
+		"navigation property": {
+			filter:   "Customer/Name eq 'amy'",
+			expected: &SyntaxError{Position: 0, Message: "navigation properties are not supported"},
+		},
+		"unclosed parentheses": {
+			filter:   "(Age ge 3",
+			expected: &SyntaxError{Position: 9, Message: "expected ')'"},
+		},
+		"trailing tokens": {
+			filter:   "Age ge 3 Name",
+			expected: &SyntaxError{Position: 9, Message: "unexpected 'Name'"},
+		},
+		"unexpected character": {
+			filter:   "Age ge $3",
+			expected: &SyntaxError{Position: 7, Message: "unexpected '$'"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := Parse(testData.filter)
+
+			// Assert
+			assert.Equal(t, testData.expected, err)
+		})
+	}
+}
+
+func TestParse_AllowedProperties_RejectsOtherProperties(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter string
+		error  string
+	}{
+		"allowed properties": {
+			filter: "Age ge 30 and startswith(tolower(Name),'jes')",
+		},
+		"other property": {
+			filter: "Age ge 30 and PasswordHash eq 'a'",
+			error:  "unknown property 'PasswordHash' at position 14",
+		},
+		"other property in a function": {
+			filter: "not contains(Email,'@')",
+			error:  "unknown property 'Email' at position 13",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := Parse(testData.filter, AllowedProperties("Age", "Name"))
+
+			// Assert
+			if testData.error == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrUnknownProperty)
+			assert.EqualError(t, err, testData.error)
+		})
+	}
+}
+
+func TestSyntaxError_Error_ReturnsExpectedMessage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	err := &SyntaxError{Position: 4, Message: "expected comparison operator"}
+
+	// Act
+	result := err.Error()
+
+	// Assert
+	assert.Equal(t, "expected comparison operator at position 4", result)
+}
+
+func TestParse_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectA struct {
+		Name string
+		Age  int
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectA{})
+
+	existing := []ObjectA{{Name: "Jessica", Age: 31}, {Name: "Jess", Age: 29}, {Name: "Amy", Age: 30}}
+	if err := db.CreateInBatches(existing, 10).Error; err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	condition, err := Parse("Age ge 30 and startswith(Name,'Jes')")
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectA
+	err = db.Scopes(gormqonvert.Scope(condition)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectA{{Name: "Jessica", Age: 31}}, actual)
+}
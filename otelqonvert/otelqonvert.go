@@ -0,0 +1,100 @@
+// Package otelqonvert adds the shape of converted filters to OpenTelemetry spans, so slow queries can be traced back
+// to the filters that caused them.
+//
+// The columns, operators and values of the converted conditions are added to the span in the context of the query as
+// the attributes db.filter.columns, db.filter.operators and db.filter.values, in the same order. Values are redacted
+// unless ShowValues() or WithRedactor() is used.
+package otelqonvert
+
+import (
+	"context"
+	"fmt"
+
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ColumnsKey contains the columns of the converted conditions
+	ColumnsKey = attribute.Key("db.filter.columns")
+
+	// OperatorsKey contains the operators of the converted conditions
+	OperatorsKey = attribute.Key("db.filter.operators")
+
+	// ValuesKey contains the redacted values of the converted conditions
+	ValuesKey = attribute.Key("db.filter.values")
+)
+
+// Redacted replaces values by default
+const Redacted = "[redacted]"
+
+// Setting can be given to SpanAttributes() to tweak its behaviour
+type Setting func(config *config)
+
+type config struct {
+	redact func(column string, value any) string
+}
+
+// WithRedactor replaces the function that redacts values, it receives the column and value of a condition and
+// returns what is added to the span
+func WithRedactor(redact func(column string, value any) string) Setting {
+	return func(config *config) {
+		config.redact = redact
+	}
+}
+
+// ShowValues makes it so that values are added to the span without redacting them, which should only be used if
+// the filters don't contain sensitive data
+func ShowValues() Setting {
+	return WithRedactor(func(_ string, value any) string {
+		return fmt.Sprint(value)
+	})
+}
+
+// SpanAttributes returns an option for the plugin that adds the columns, operators and values of the converted
+// conditions of a query to the span in its context, which can be set using db.WithContext().
+func SpanAttributes(settings ...Setting) gormqonvert.Option {
+	config := &config{
+		redact: func(string, any) string {
+			return Redacted
+		},
+	}
+
+	for _, setting := range settings {
+		setting(config)
+	}
+
+	return gormqonvert.OnQuery(func(ctx context.Context, conversions []gormqonvert.Conversion) {
+		span := trace.SpanFromContext(ctx)
+		if !span.IsRecording() {
+			return
+		}
+
+		var columns, operators, values []string
+
+		for _, conversion := range conversions {
+			for _, condition := range leaves(conversion.Condition) {
+				columns = append(columns, condition.Column)
+				operators = append(operators, string(condition.Operator))
+				values = append(values, config.redact(condition.Column, condition.Value))
+			}
+		}
+
+		span.SetAttributes(ColumnsKey.StringSlice(columns), OperatorsKey.StringSlice(operators), ValuesKey.StringSlice(values))
+	})
+}
+
+// leaves returns the conditions that aren't groups
+func leaves(condition gormqonvert.Condition) []gormqonvert.Condition {
+	if condition.Conditions == nil {
+		return []gormqonvert.Condition{condition}
+	}
+
+	var result []gormqonvert.Condition
+	for _, nested := range condition.Conditions {
+		result = append(result, leaves(nested)...)
+	}
+
+	return result
+}
@@ -0,0 +1,92 @@
+package otelqonvert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanAttributes_AddsFilterToSpan(t *testing.T) {
+	t.Parallel()
+
+	type ObjectA struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		settings []Setting
+		filter   map[string]any
+		expected []attribute.KeyValue
+	}{
+		"nothing converted": {
+			filter:   map[string]any{"name": "jessica"},
+			expected: nil,
+		},
+		"redacted values": {
+			filter: map[string]any{"age": ">=30", "name": []string{"~j%", "amy"}},
+			expected: []attribute.KeyValue{
+				ColumnsKey.StringSlice([]string{"age", "name", "name"}),
+				OperatorsKey.StringSlice([]string{">=", "LIKE", "="}),
+				ValuesKey.StringSlice([]string{Redacted, Redacted, Redacted}),
+			},
+		},
+		"shown values": {
+			settings: []Setting{ShowValues()},
+			filter:   map[string]any{"age": ">=30"},
+			expected: []attribute.KeyValue{
+				ColumnsKey.StringSlice([]string{"age"}),
+				OperatorsKey.StringSlice([]string{">="}),
+				ValuesKey.StringSlice([]string{"30"}),
+			},
+		},
+		"custom redactor": {
+			settings: []Setting{WithRedactor(func(column string, value any) string {
+				if column == "age" {
+					return "number"
+				}
+
+				return Redacted
+			})},
+			filter: map[string]any{"age": ">=30", "name": "~j%"},
+			expected: []attribute.KeyValue{
+				ColumnsKey.StringSlice([]string{"age", "name"}),
+				OperatorsKey.StringSlice([]string{">=", "LIKE"}),
+				ValuesKey.StringSlice([]string{"number", Redacted}),
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			config := gormqonvert.CharacterConfig{GreaterOrEqualToPrefix: ">=", LikePrefix: "~"}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectA{})
+			_ = db.Use(gormqonvert.New(config, SpanAttributes(testData.settings...)))
+
+			ctx, span := provider.Tracer("test").Start(context.Background(), "query")
+
+			// Act
+			err := db.WithContext(ctx).Where(testData.filter).Find(&[]ObjectA{}).Error
+
+			// Assert
+			span.End()
+
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, exporter.GetSpans()[0].Attributes)
+		})
+	}
+}
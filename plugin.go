@@ -1,6 +1,8 @@
 package gormqonvert
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -19,6 +21,10 @@ type CharacterConfig struct {
 	NotEqualToPrefix       string
 	LikePrefix             string
 	NotLikePrefix          string
+
+	// EqualToPrefix is optional and only strips itself from the value, it can be used to escape values that would
+	// otherwise start with one of the other prefixes
+	EqualToPrefix string
 }
 
 // SettingOnly makes it so that only queries with the setting 'gormQonvert' set to true can be turned into LIKE queries.
@@ -29,10 +35,193 @@ func SettingOnly() Option {
 	}
 }
 
+// BracketKeys makes it so that keys like 'age[gte]' or 'name[like]' are converted using the operator in the
+// brackets, see ParseBrackets for the supported operators. Their values are used as-is and won't be checked
+// for prefixes.
+func BracketKeys() Option {
+	return func(like *gormQonvert) {
+		like.bracketKeys = true
+	}
+}
+
+// DjangoKeys makes it so that keys like 'age__gte' or 'name__icontains' are converted using the lookup after the
+// double underscore, see the README for the supported lookups. Their values are used as-is and won't be checked
+// for prefixes.
+func DjangoKeys() Option {
+	return func(like *gormQonvert) {
+		like.djangoKeys = true
+	}
+}
+
+// MongoOperators makes it so that MongoDB-style documents like {"age": {"$gte": 30}} are converted, see ParseMongo
+// for the supported operators. Invalid documents are reported as an error on the query.
+func MongoOperators() Option {
+	return func(like *gormQonvert) {
+		like.mongoOperators = true
+	}
+}
+
+// WithSearchKey registers a virtual key that searches through multiple columns, a filter like {"q": "jess"} will then
+// match records where any of the columns contain the value. Wildcards in the value are escaped and if multiple
+// values are given, all of them have to be found.
+func WithSearchKey(key string, columns ...string) Option {
+	return func(like *gormQonvert) {
+		like.searchKeys[key] = searchKey{columns: columns}
+	}
+}
+
+// WithCaseInsensitiveSearchKey is like WithSearchKey, but ignores the case of the columns and values.
+func WithCaseInsensitiveSearchKey(key string, columns ...string) Option {
+	return func(like *gormQonvert) {
+		like.searchKeys[key] = searchKey{columns: columns, ignoreCase: true}
+	}
+}
+
+// WithAliases makes it so that public filter names can be used instead of their columns, a filter like
+// {"createdAt": ">2024"} with the alias "createdAt" for "created_at" will then filter on the column. Aliases are
+// also resolved in the other key syntaxes, like 'createdAt[gte]'.
+func WithAliases(aliases map[string]string) Option {
+	return func(like *gormQonvert) {
+		for alias, column := range aliases {
+			like.aliases[alias] = column
+		}
+	}
+}
+
+// JSONTagAliases makes it so that the names in the json-tags of the model's fields are aliases of their columns,
+// see WithAliases.
+func JSONTagAliases() Option {
+	return func(like *gormQonvert) {
+		like.jsonTagAliases = true
+	}
+}
+
+// RelationKeys makes it so that keys like 'customer.country' filter on the columns of the model's relations, using
+// an EXISTS-subquery. Relations can be nested, like 'customer.address.city'.
+func RelationKeys() Option {
+	return func(like *gormQonvert) {
+		like.relationKeys = true
+	}
+}
+
+// RequireIndexes makes it so that converted conditions on columns without an index are rejected by adding
+// ErrNotIndexed to the query. Primary keys, unique fields, the first fields of the model's indexes and the
+// given columns are considered indexed.
+func RequireIndexes(columns ...string) Option {
+	return func(like *gormQonvert) {
+		like.requireIndexes = true
+
+		for _, column := range columns {
+			like.indexedColumns[column] = true
+		}
+	}
+}
+
+// RejectLeadingWildcards makes it so that LIKE-patterns that start with a wildcard are rejected by adding
+// ErrLeadingWildcard to the query, since those can't use an index.
+func RejectLeadingWildcards() Option {
+	return func(like *gormQonvert) {
+		like.rejectLeadingWildcards = true
+	}
+}
+
+// LogUnindexed makes it so that conditions rejected by RequireIndexes() and RejectLeadingWildcards() are logged
+// as a warning instead.
+func LogUnindexed() Option {
+	return func(like *gormQonvert) {
+		like.logUnindexed = true
+	}
+}
+
+// WithLimits restricts how much of a query is converted, queries that exceed the limits get a *LimitError
+func WithLimits(limits Limits) Option {
+	return func(like *gormQonvert) {
+		like.limits = limits
+	}
+}
+
+// Strict makes it so that filters that can't be converted are not silently left alone, instead a *FilterError is
+// added to the query. Columns are checked against the model, values against the type of their column and operators
+// against the key syntaxes and column types.
+func Strict() Option {
+	return func(like *gormQonvert) {
+		like.strict = true
+	}
+}
+
+// RelativeDates makes it so that values of time fields can be relative dates like 'now-7d', 'today' or
+// 'start_of_month', absolute dates like '2024-01-02' or '2024-01' and ranges like '2024-01..2024-03'. Dates like
+// 'today' are periods, so 'today' matches the whole day and '>today' starts tomorrow. Offsets are given in s, m, h,
+// d, w, M or y.
+func RelativeDates() Option {
+	return func(like *gormQonvert) {
+		like.relativeDates = true
+	}
+}
+
+// WithClock replaces the clock that RelativeDates() uses to find the current time, which is time.Now by default
+func WithClock(clock func() time.Time) Option {
+	return func(like *gormQonvert) {
+		like.clock = clock
+	}
+}
+
+// WithLocation sets the time zone in which RelativeDates() resolves dates and in which dates without a time zone are
+// parsed, by default the time zone of the clock is used
+func WithLocation(location *time.Location) Option {
+	return func(like *gormQonvert) {
+		like.location = location
+	}
+}
+
+// WithDateLayouts converts values of time fields that match one of the layouts, like time.RFC3339 or time.DateOnly,
+// to times instead of leaving it to the database driver. Layouts without a time of day describe the whole day, so
+// '<=2024-03-01' includes the 1st of March. RelativeDates() uses the layouts for its absolute dates.
+func WithDateLayouts(layouts ...string) Option {
+	return func(like *gormQonvert) {
+		like.absoluteDates = true
+		like.dateLayouts = newDateLayouts(layouts)
+	}
+}
+
+// WithValueExtractor replaces the function that finds the text of values, which is PlainStrings by default. Use
+// ReflectStrings to also convert values of types like `type Status string`, *string or sql.NullString.
+func WithValueExtractor(extractor ValueExtractor) Option {
+	return func(like *gormQonvert) {
+		like.extractValue = extractor
+	}
+}
+
+// WithNumberFormat converts values of numeric fields to numbers using the format, so '1.000,5' or '>=5k' can be
+// used if the format allows it. Numbers that don't fit the size of an integer field add a *FilterError that wraps
+// ErrOutOfRange to the query.
+func WithNumberFormat(format NumberFormat) Option {
+	return func(like *gormQonvert) {
+		like.numberFormat = &format
+	}
+}
+
+// WithVirtualField makes it possible to filter on an SQL expression as if it were a column, a filter like
+// {"full_name": "~jes%"} then compares the expression of the field to the value.
+func WithVirtualField(name string, field VirtualField) Option {
+	return func(like *gormQonvert) {
+		like.virtualFields[name] = field
+	}
+}
+
 // New creates a new instance of the plugin that can be registered in gorm. Without any settings, all queries will be
 // LIKE-d.
 func New(config CharacterConfig, opts ...Option) gorm.Plugin {
-	plugin := &gormQonvert{config: config}
+	plugin := &gormQonvert{
+		config:         config,
+		searchKeys:     map[string]searchKey{},
+		aliases:        map[string]string{},
+		virtualFields:  map[string]VirtualField{},
+		indexedColumns: map[string]bool{},
+		clock:          time.Now,
+		dateLayouts:    defaultDateLayouts,
+		extractValue:   PlainStrings,
+	}
 
 	for _, opt := range opts {
 		opt(plugin)
@@ -43,7 +232,37 @@ func New(config CharacterConfig, opts ...Option) gorm.Plugin {
 
 type gormQonvert struct {
 	conditionalSetting bool
+	bracketKeys        bool
+	djangoKeys         bool
+	mongoOperators     bool
+	jsonTagAliases     bool
+	relationKeys       bool
+	strict             bool
+	relativeDates      bool
+	absoluteDates      bool
+
+	requireIndexes         bool
+	rejectLeadingWildcards bool
+	logUnindexed           bool
+
+	searchKeys    map[string]searchKey
+	aliases       map[string]string
+	virtualFields map[string]VirtualField
+
+	indexedColumns map[string]bool
+
+	convertHooks []ConvertHook
+	queryHooks   []QueryHook
+	skipHooks    []SkipHook
+
+	clock       func() time.Time
+	location    *time.Location
+	dateLayouts []dateLayout
+
+	numberFormat *NumberFormat
+	extractValue ValueExtractor
 
+	limits Limits
 	config CharacterConfig
 }
 
@@ -1,7 +1,6 @@
 package gormqonvert
 
 import (
-	"fmt"
 	"strings"
 
 	"gorm.io/gorm"
@@ -10,129 +9,335 @@ import (
 
 const tagName = "gormQonvert"
 
-func (d *gormQonvert) replaceExpressions(db *gorm.DB, expressions []clause.Expression) []clause.Expression {
+func (d *gormQonvert) replaceExpressions(db *gorm.DB, expressions []clause.Expression, depth int) []clause.Expression {
 	for index, cond := range expressions {
 		switch cond := cond.(type) {
 		case clause.AndConditions:
+			if !checkLimit(db, "depth", d.limits.Depth, depth+1) {
+				continue
+			}
+
 			// Recursively go through the expressions of AndConditions
-			cond.Exprs = d.replaceExpressions(db, cond.Exprs)
+			cond.Exprs = d.replaceExpressions(db, cond.Exprs, depth+1)
 			expressions[index] = cond
 		case clause.OrConditions:
+			if !checkLimit(db, "depth", d.limits.Depth, depth+1) {
+				continue
+			}
+
 			// Recursively go through the expressions of OrConditions
-			cond.Exprs = d.replaceExpressions(db, cond.Exprs)
+			cond.Exprs = d.replaceExpressions(db, cond.Exprs, depth+1)
 			expressions[index] = cond
 		case clause.Eq:
-			value, ok := cond.Value.(string)
+			column, ok := cond.Column.(clause.Column)
 			if !ok {
 				continue
 			}
 
-			var condition string
+			name := column.Name
+			if alias, ok := d.alias(db, name); ok {
+				column.Name = alias
+				cond.Column = tableColumn(column.Table, alias)
+				expressions[index] = cond
+			}
+
+			if d.isMongoCondition(name, cond.Value) {
+				d.replaceMongo(db, expressions, index, name, column.Table, cond.Value, depth)
+				continue
+			}
+
+			condition, ok, err := d.keyCondition(name, []any{cond.Value})
+			if err != nil && d.strict {
+				_ = db.AddError(err)
+				continue
+			}
+
+			if ok {
+				d.replace(db, expressions, index, Conversion{Key: name, Value: cond.Value, Condition: condition}, column.Table)
+				continue
+			}
 
-			column := cond.Column.(clause.Column)
+			condition = Condition{Column: column.Name, Operator: OperatorEqual, Value: cond.Value}
 
-			switch {
-			case d.config.GreaterOrEqualToPrefix != "" && strings.HasPrefix(value, d.config.GreaterOrEqualToPrefix):
-				condition = fmt.Sprintf("%s >= ?", column.Name)
-				value = value[len(d.config.GreaterOrEqualToPrefix):]
+			value, isString := d.extractValue(cond.Value)
 
-			case d.config.GreaterThanPrefix != "" && strings.HasPrefix(value, d.config.GreaterThanPrefix):
-				condition = fmt.Sprintf("%s > ?", column.Name)
-				value = value[len(d.config.GreaterThanPrefix):]
+			// Don't alter the query if it isn't necessary, virtual fields always need to be replaced
+			if operator, value, ok := d.config.parse(value); isString && ok {
+				condition.Operator = operator
+				condition.Value = value
+			} else if !d.isVirtual(db, column.Name) && !d.isDate(db, column.Name, cond.Value) && !d.isNumber(db, column.Name, cond.Value) {
+				d.checkStrict(db, condition, false)
+				d.notifySkip(db, column.Name, cond.Value)
+				continue
+			}
 
-			case d.config.LessOrEqualToPrefix != "" && strings.HasPrefix(value, d.config.LessOrEqualToPrefix):
-				condition = fmt.Sprintf("%s <= ?", column.Name)
-				value = value[len(d.config.LessOrEqualToPrefix):]
+			d.replace(db, expressions, index, Conversion{Key: name, Value: cond.Value, Condition: condition}, column.Table)
+		case clause.IN:
+			// gorm turns a []byte into an IN-check of its bytes, while it's a single value. Lists of bytes can't be
+			// told apart from it, so those are treated as text as well.
+			if text, ok := d.extractBytes(cond.Values); ok {
+				expressions[index] = clause.Eq{Column: cond.Column, Value: text}
+				d.replaceExpressions(db, expressions[index:index+1], depth)
 
-			case d.config.LessThanPrefix != "" && strings.HasPrefix(value, d.config.LessThanPrefix):
-				condition = fmt.Sprintf("%s < ?", column.Name)
-				value = value[len(d.config.LessThanPrefix):]
+				continue
+			}
 
-			case d.config.NotEqualToPrefix != "" && strings.HasPrefix(value, d.config.NotEqualToPrefix):
-				condition = fmt.Sprintf("%s != ?", column.Name)
-				value = value[len(d.config.NotEqualToPrefix):]
+			column, ok := cond.Column.(clause.Column)
+			if !ok {
+				continue
+			}
 
-			case d.config.LikePrefix != "" && strings.HasPrefix(value, d.config.LikePrefix):
-				condition = fmt.Sprintf("%s LIKE ?", column.Name)
-				value = value[len(d.config.LikePrefix):]
+			name := column.Name
+			if alias, ok := d.alias(db, name); ok {
+				column.Name = alias
+				cond.Column = tableColumn(column.Table, alias)
+				expressions[index] = cond
+			}
 
-			case d.config.NotLikePrefix != "" && strings.HasPrefix(value, d.config.NotLikePrefix):
-				condition = fmt.Sprintf("%s NOT LIKE ?", column.Name)
-				value = value[len(d.config.NotLikePrefix):]
+			if d.isMongoCondition(name, cond.Values) {
+				d.replaceMongo(db, expressions, index, name, column.Table, cond.Values, depth)
+				continue
+			}
+
+			condition, ok, err := d.keyCondition(name, cond.Values)
+			if err != nil && d.strict {
+				_ = db.AddError(err)
+				continue
+			}
+
+			if ok {
+				if checkLimit(db, "values", d.limits.Values, len(cond.Values)) {
+					d.replace(db, expressions, index, Conversion{Key: name, Value: cond.Values, Condition: condition}, column.Table)
+				}
 
-			default:
 				continue
 			}
 
-			expressions[index] = db.Session(&gorm.Session{NewDB: true}).Where(condition, value).Statement.Clauses["WHERE"].Expression
-		case clause.IN:
 			var conversionCounter int
-			var useOr bool
 
-			query := db.Session(&gorm.Session{NewDB: true})
+			alternatives := make([]Condition, len(cond.Values))
+			for valueIndex, value := range cond.Values {
+				alternatives[valueIndex] = Condition{Column: column.Name, Operator: OperatorEqual, Value: value}
 
-			for _, value := range cond.Values {
-				value, ok := value.(string)
+				stringValue, ok := d.extractValue(value)
 				if !ok {
 					continue
 				}
 
-				condition := fmt.Sprintf("%s = ?", cond.Column)
+				operator, stringValue, ok := d.config.parse(stringValue)
+				if !ok {
+					if d.isDate(db, column.Name, value) || d.isNumber(db, column.Name, value) {
+						conversionCounter++
+					}
 
-				column := cond.Column.(clause.Column)
+					continue
+				}
 
-				switch {
-				case d.config.GreaterOrEqualToPrefix != "" && strings.HasPrefix(value, d.config.GreaterOrEqualToPrefix):
-					condition = fmt.Sprintf("%s >= ?", column.Name)
-					value = value[len(d.config.GreaterOrEqualToPrefix):]
+				conversionCounter++
+				alternatives[valueIndex].Operator = operator
+				alternatives[valueIndex].Value = stringValue
+			}
 
-				case d.config.GreaterThanPrefix != "" && strings.HasPrefix(value, d.config.GreaterThanPrefix):
-					condition = fmt.Sprintf("%s > ?", column.Name)
-					value = value[len(d.config.GreaterThanPrefix):]
+			// Don't alter the query if it isn't necessary
+			if conversionCounter == 0 && !d.isVirtual(db, column.Name) {
+				d.checkStrict(db, Condition{Column: column.Name, Operator: OperatorIn, Value: cond.Values}, false)
+				d.notifySkip(db, column.Name, cond.Values)
+				continue
+			}
 
-				case d.config.LessOrEqualToPrefix != "" && strings.HasPrefix(value, d.config.LessOrEqualToPrefix):
-					condition = fmt.Sprintf("%s <= ?", column.Name)
-					value = value[len(d.config.LessOrEqualToPrefix):]
+			if !checkLimit(db, "values", d.limits.Values, len(cond.Values)) {
+				continue
+			}
 
-				case d.config.LessThanPrefix != "" && strings.HasPrefix(value, d.config.LessThanPrefix):
-					condition = fmt.Sprintf("%s < ?", column.Name)
-					value = value[len(d.config.LessThanPrefix):]
+			d.replace(db, expressions, index, Conversion{Key: name, Value: cond.Values, Condition: Or(alternatives...)}, column.Table)
+		}
+	}
+	return expressions
+}
 
-				case d.config.NotEqualToPrefix != "" && strings.HasPrefix(value, d.config.NotEqualToPrefix):
-					condition = fmt.Sprintf("%s != ?", column.Name)
-					value = value[len(d.config.NotEqualToPrefix):]
+// replace replaces the expression at the index with the condition of the conversion, after replacing aliases with
+// their columns. The conversion is recorded if the query is run by Explain() and passed to the hooks, unless one of
+// its conditions was rejected.
+func (d *gormQonvert) replace(db *gorm.DB, expressions []clause.Expression, index int, conversion Conversion, table string) {
+	conversion.Condition = conversion.Condition.renameColumns(func(name string) string {
+		if alias, ok := d.alias(db, name); ok {
+			return alias
+		}
 
-				case d.config.LikePrefix != "" && strings.HasPrefix(value, d.config.LikePrefix):
-					condition = fmt.Sprintf("%s LIKE ?", column.Name)
-					value = value[len(d.config.LikePrefix):]
+		return name
+	})
 
-				case d.config.NotLikePrefix != "" && strings.HasPrefix(value, d.config.NotLikePrefix):
-					condition = fmt.Sprintf("%s NOT LIKE ?", column.Name)
-					value = value[len(d.config.NotLikePrefix):]
+	condition, err := d.resolveValues(db, conversion.Condition)
+	if err != nil {
+		_ = db.AddError(err)
+		expressions[index] = rejectedExpression
 
-				default:
-					continue
-				}
+		return
+	}
 
-				conversionCounter++
-				if useOr {
-					query = query.Or(condition, value)
-					continue
-				}
+	conversion.Condition = condition
+
+	_, searched := d.searchKeys[conversion.Key]
+
+	accepted := true
+
+	expressions[index] = conversion.Condition.expression(func(condition Condition) clause.Expression {
+		expression, ok := d.comparison(db, condition, table, searched)
+		accepted = accepted && ok
+
+		return expression
+	})
+
+	if !accepted {
+		return
+	}
+
+	recordConversion(db, conversion)
+	d.notifyConvert(db, conversion)
+}
 
-				query = query.Where(condition, value)
-				useOr = true
+// resolveValues replaces the dates and numbers in the condition and its nested conditions, so conversions hold the
+// values that are compared to the columns. The error is a *FilterError if a number does not fit its field.
+func (d *gormQonvert) resolveValues(db *gorm.DB, condition Condition) (Condition, error) {
+	if condition.Conditions != nil {
+		conditions := make([]Condition, len(condition.Conditions))
+		for index, nested := range condition.Conditions {
+			resolved, err := d.resolveValues(db, nested)
+			if err != nil {
+				return Condition{}, err
 			}
 
-			// Don't alter the query if it isn't necessary
-			if conversionCounter == 0 {
-				continue
+			conditions[index] = resolved
+		}
+
+		condition.Conditions = conditions
+
+		return condition, nil
+	}
+
+	// Dates are resolved into conditions with times, which are compared like any other condition
+	if resolved, ok := d.resolveDates(db, condition); ok {
+		return resolved, nil
+	}
+
+	// Numbers are resolved before checking them, since values like '5k' don't fit their column otherwise
+	resolved, ok, err := d.resolveNumbers(db, condition)
+	if err != nil {
+		return Condition{}, err
+	}
+
+	if ok {
+		return resolved, nil
+	}
+
+	return condition, nil
+}
+
+// comparison turns a condition that isn't a group into a gorm expression, which compares the values to a column,
+// a virtual field or a column of a relation. Searched is true if the condition was created by a search key. The
+// result is not ok if the condition was rejected, the error is added to the query in that case.
+func (d *gormQonvert) comparison(db *gorm.DB, condition Condition, table string, searched bool) (clause.Expression, bool) {
+	if !d.checkStrict(db, condition, searched) || !d.checkLimits(db, condition) || !d.checkIndex(db, condition) {
+		return rejectedExpression, false
+	}
+
+	if field, ok := d.virtualFields[condition.Column]; ok {
+		return condition.comparison(db, field.expression(db)), true
+	}
+
+	if d.relationKeys && db.Statement.Schema != nil {
+		if expression, ok := relationExpression(db, db.Statement.Schema, table, "", condition); ok {
+			return expression, true
+		}
+	}
+
+	return condition.comparison(db, tableColumn(table, condition.Column)), true
+}
+
+// isVirtual returns true if the name refers to a virtual field or the column of a relation instead of a column,
+// these are always converted since they don't exist in the table
+func (d *gormQonvert) isVirtual(db *gorm.DB, name string) bool {
+	if _, ok := d.virtualFields[name]; ok {
+		return true
+	}
+
+	if !d.relationKeys || db.Statement.Schema == nil {
+		return false
+	}
+
+	_, _, ok := findRelation(db.Statement.Schema, name)
+
+	return ok
+}
+
+// isMongoCondition returns true if the key or value is part of a MongoDB-style document and MongoOperators is enabled
+func (d *gormQonvert) isMongoCondition(name string, value any) bool {
+	if !d.mongoOperators {
+		return false
+	}
+
+	_, isDocument := value.(map[string]any)
+
+	return isDocument || strings.HasPrefix(name, "$")
+}
+
+// replaceMongo replaces the expression at the index with the converted MongoDB-style document, errors are added to
+// the query since the original expression would fail anyway. Nested $or- and $and-documents count towards the depth
+// limit like gorm's groups do.
+func (d *gormQonvert) replaceMongo(db *gorm.DB, expressions []clause.Expression, index int, name string, table string, value any, depth int) {
+	if !checkLimit(db, "depth", d.limits.Depth, depth+mongoDepth(name, value)) {
+		return
+	}
+
+	condition, err := mongoCondition(name, value)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	d.replace(db, expressions, index, Conversion{Key: name, Value: value, Condition: condition}, table)
+}
+
+// keyCondition creates a condition if the column name is a search key or contains an operator in one of the enabled
+// key syntaxes. The error is a *FilterError if the key has an operator, but it's unknown or the values don't fit it.
+func (d *gormQonvert) keyCondition(name string, values []any) (Condition, bool, error) {
+	if len(values) == 0 {
+		return Condition{}, false, nil
+	}
+
+	if search, ok := d.searchKeys[name]; ok {
+		return search.condition(values), true, nil
+	}
+
+	if d.bracketKeys {
+		if column, operatorName, ok := splitBracketKey(name); ok {
+			operator, ok := bracketOperators[operatorName]
+			if !ok {
+				return Condition{}, false, &FilterError{Err: ErrOperatorNotAllowed, Column: column, Value: values[0], Operator: Operator(operatorName)}
 			}
 
-			expressions[index] = db.Session(&gorm.Session{NewDB: true}).Where(query).Statement.Clauses["WHERE"].Expression
+			return validKeyCondition(Condition{Column: column, Operator: operator}, values)
 		}
 	}
-	return expressions
+
+	if d.djangoKeys {
+		if template, ok := splitDjangoKey(name); ok {
+			return validKeyCondition(template, values)
+		}
+	}
+
+	return Condition{}, false, nil
+}
+
+// validKeyCondition creates the condition of a key using keyCondition, the error is a *FilterError if the values
+// don't fit the operator
+func validKeyCondition(template Condition, values []any) (Condition, bool, error) {
+	condition, ok := keyCondition(template, values)
+	if !ok {
+		return Condition{}, false, &FilterError{Err: ErrInvalidValue, Column: template.Column, Value: values[0], Operator: template.Operator}
+	}
+
+	return condition, true, nil
 }
 
 func (d *gormQonvert) queryCallback(db *gorm.DB) {
@@ -148,10 +353,18 @@ func (d *gormQonvert) queryCallback(db *gorm.DB) {
 		}
 	}
 
-	exp, settingOk := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	expression := db.Statement.Clauses["WHERE"].Expression
+
+	exp, settingOk := expression.(clause.Where)
 	if !settingOk {
+		if expression != nil {
+			d.reportStrict(db, ErrUnsupportedClause, Condition{Value: expression})
+		}
+
 		return
 	}
 
-	exp.Exprs = d.replaceExpressions(db, exp.Exprs)
+	exp.Exprs = d.replaceExpressions(db, exp.Exprs, 0)
+
+	d.notifyQuery(db)
 }
@@ -342,6 +342,195 @@ func TestGormQonvert_Initialize_TriggersConversionCorrectly(t *testing.T) {
 				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
 			},
 		},
+		"like and plain multiple values": {
+			filter: []map[string]any{{
+				"name": []string{"~%ssica", "amy"},
+			}},
+			query: defaultQuery,
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+			},
+		},
+		"bracket keys": {
+			filter: []map[string]any{{
+				"age[gte]":  30,
+				"name[nin]": "amy,jochem",
+			}},
+			query:   defaultQuery,
+			options: []Option{BracketKeys()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+				{ID: uuid.MustParse("699204f0-26f0-4e02-9e25-b73ac0b2300b"), Name: "jochem", Age: 36},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+		},
+		"bracket keys with multiple values": {
+			filter: []map[string]any{{
+				"name[in]":   []string{"amy", "boris"},
+				"name[like]": []string{"%a%", "%o%"},
+			}},
+			query:   defaultQuery,
+			options: []Option{BracketKeys()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+		},
+		"bracket keys don't convert prefixes": {
+			filter: []map[string]any{{
+				"name[eq]": "~amy",
+			}},
+			query:   defaultQuery,
+			options: []Option{BracketKeys()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "~amy", Age: 31},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "~amy", Age: 31},
+			},
+		},
+		"django keys": {
+			filter: []map[string]any{{
+				"age__range":      []int{30, 35},
+				"name__icontains": "O",
+				"name__isnull":    "false",
+			}},
+			query:   defaultQuery,
+			options: []Option{DjangoKeys()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+				{ID: uuid.MustParse("699204f0-26f0-4e02-9e25-b73ac0b2300b"), Name: "jochem", Age: 36},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+		},
+		"django keys escape wildcards": {
+			filter: []map[string]any{{
+				"name__startswith": "a_",
+				"age__in":          "30,31",
+			}},
+			query:   defaultQuery,
+			options: []Option{DjangoKeys()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "a_y", Age: 31},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "a_y", Age: 31},
+			},
+		},
+		"django keys don't convert prefixes": {
+			filter: []map[string]any{{
+				"age__gte": ">30",
+			}},
+			query:   defaultQuery,
+			options: []Option{DjangoKeys()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+			expected: []ObjectA{},
+		},
+		"mongo operators": {
+			filter: []map[string]any{{
+				"age":  map[string]any{"$gte": 30, "$lt": 36},
+				"name": map[string]any{"$nin": []string{"amy"}, "$exists": true},
+			}},
+			query:   defaultQuery,
+			options: []Option{MongoOperators()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+				{ID: uuid.MustParse("699204f0-26f0-4e02-9e25-b73ac0b2300b"), Name: "jochem", Age: 36},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+		},
+		"mongo or": {
+			filter: []map[string]any{{
+				"$or": []map[string]any{
+					{"age": map[string]any{"$lt": 30}},
+					{"name": "jochem", "age": map[string]any{"$in": []int{36}}},
+				},
+			}},
+			query:   defaultQuery,
+			options: []Option{MongoOperators()},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("699204f0-26f0-4e02-9e25-b73ac0b2300b"), Name: "jochem", Age: 36},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("699204f0-26f0-4e02-9e25-b73ac0b2300b"), Name: "jochem", Age: 36},
+			},
+		},
+		"search key": {
+			filter: []map[string]any{{
+				"q": "3",
+			}},
+			query:   defaultQuery,
+			options: []Option{WithSearchKey("q", "name", "age")},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "b3ris", Age: 21},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "b3ris", Age: 21},
+			},
+		},
+		"search key with multiple values": {
+			filter: []map[string]any{{
+				"q": []string{"2", "i"},
+			}},
+			query:   defaultQuery,
+			options: []Option{WithSearchKey("q", "name", "age")},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 20},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "boris", Age: 31},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("49d3c60b-48e0-4bc8-a144-b0d823cd1373"), Name: "jessica", Age: 29},
+			},
+		},
+		"search key escapes wildcards": {
+			filter: []map[string]any{{
+				"q": "A_",
+			}},
+			query:   defaultQuery,
+			options: []Option{WithCaseInsensitiveSearchKey("q", "name")},
+			existing: []ObjectA{
+				{ID: uuid.MustParse("d8e2b086-21d4-4671-b1e8-cedc97a804a6"), Name: "amy", Age: 30},
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "a_y", Age: 31},
+			},
+			expected: []ObjectA{
+				{ID: uuid.MustParse("2709499e-8666-4775-959b-24289a6eabff"), Name: "a_y", Age: 31},
+			},
+		},
 		// With existing query
 		"greater or equal to value with existing query": {
 			filter: []map[string]any{{
@@ -567,3 +756,60 @@ func TestGormQonvert_Initialize_TriggersConversionCorrectlyWithSetting(t *testin
 		})
 	}
 }
+
+func TestGormQonvert_Initialize_EscapesEqualToValues(t *testing.T) {
+	t.Parallel()
+
+	type ObjectR struct {
+		Name string
+	}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		existing []ObjectR
+		expected []ObjectR
+	}{
+		"escaped like prefix": {
+			filter:   map[string]any{"name": "=~amy"},
+			existing: []ObjectR{{Name: "amy"}, {Name: "~amy"}},
+			expected: []ObjectR{{Name: "~amy"}},
+		},
+		"escaped equal to prefix": {
+			filter:   map[string]any{"name": "==amy"},
+			existing: []ObjectR{{Name: "amy"}, {Name: "=amy"}},
+			expected: []ObjectR{{Name: "=amy"}},
+		},
+		"escaped values in a list": {
+			filter:   map[string]any{"name": []string{"=~amy", "~bor%"}},
+			existing: []ObjectR{{Name: "amy"}, {Name: "~amy"}, {Name: "boris"}},
+			expected: []ObjectR{{Name: "boris"}, {Name: "~amy"}},
+		},
+		"like prefix without escape": {
+			filter:   map[string]any{"name": "~amy"},
+			existing: []ObjectR{{Name: "amy"}, {Name: "~amy"}},
+			expected: []ObjectR{{Name: "amy"}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectR{})
+			_ = db.Use(New(CharacterConfig{LikePrefix: "~", EqualToPrefix: "="}))
+
+			_ = db.Create(testData.existing).Error
+
+			var actual []ObjectR
+
+			// Act
+			err := db.Where(testData.filter).Order("name").Find(&actual).Error
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
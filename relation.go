@@ -0,0 +1,200 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// aggregateFunctions maps the last part of a key like 'orders.amount.sum' to the SQL of its aggregate function,
+// with a placeholder for the column
+var aggregateFunctions = map[string]string{
+	"count": "COUNT(?)",
+	"sum":   "COALESCE(SUM(?), 0)",
+	"min":   "MIN(?)",
+	"max":   "MAX(?)",
+}
+
+// findRelation finds the relation that the first part of a dotted name like 'customer.country' refers to and returns
+// the rest of the name. Relations are matched by their field name, ignoring case and underscores.
+func findRelation(modelSchema *schema.Schema, name string) (*schema.Relationship, string, bool) {
+	relationName, rest, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil, "", false
+	}
+
+	relationName = strings.ReplaceAll(relationName, "_", "")
+
+	for _, relation := range modelSchema.Relationships.Relations {
+		if strings.EqualFold(relation.Name, relationName) {
+			return relation, rest, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// relationExpression turns a condition on the column of a relation into an EXISTS-subquery that checks if a related
+// record matches the condition. Aggregates like 'orders.count' or 'orders.amount.sum' are compared using a subquery
+// that calculates them instead. The related table gets an alias like gorm's joins, consisting of the relation names
+// separated by '__', to prevent conflicts in relations that refer to the same table. The result is not ok if the
+// column does not refer to a relation of the schema.
+func relationExpression(db *gorm.DB, modelSchema *schema.Schema, table string, path string, condition Condition) (clause.Expression, bool) {
+	relation, rest, ok := findRelation(modelSchema, condition.Column)
+	if !ok {
+		return nil, false
+	}
+
+	alias := relation.Name
+	if path != "" {
+		alias = path + "__" + relation.Name
+	}
+
+	from, vars, expressions := relationSubquery(relation, table, alias)
+
+	condition.Column = rest
+
+	// Nested relations get their own subquery within this one
+	inner, ok := relationExpression(db, relation.FieldSchema, alias, alias, condition)
+	if !ok {
+		if function, ok := aggregateFunction(relation.FieldSchema, alias, rest); ok {
+			sql := fmt.Sprintf("(SELECT %s FROM %s WHERE ?)", function.sql, from)
+			vars = append(append([]any{function.column}, vars...), clause.And(expressions...))
+
+			if function.numeric {
+				condition.Value = numericValue(condition.Value)
+			}
+
+			return condition.comparison(db, clause.Expr{SQL: sql, Vars: vars}), true
+		}
+
+		inner = condition.comparison(db, relationColumn(relation.FieldSchema, alias, rest))
+	}
+
+	sql := fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE ?)", from)
+	vars = append(vars, clause.And(append(expressions, inner)...))
+
+	return clause.Expr{SQL: sql, Vars: vars}, true
+}
+
+// relationSubquery returns the FROM-part of a subquery on the related table, its tables and the expressions that
+// link the related records to the ones in the table
+func relationSubquery(relation *schema.Relationship, table string, alias string) (string, []any, []clause.Expression) {
+	from := "?"
+	tables := []any{clause.Table{Name: relation.FieldSchema.Table, Alias: alias}}
+
+	foreignTable := alias
+	if relation.JoinTable != nil {
+		foreignTable = relation.JoinTable.Table
+
+		from = "?, ?"
+		tables = append(tables, clause.Table{Name: foreignTable})
+	}
+
+	expressions := make([]clause.Expression, 0, len(relation.References)+1)
+
+	for _, reference := range relation.References {
+		foreignKey := clause.Column{Table: foreignTable, Name: reference.ForeignKey.DBName}
+
+		switch {
+		case reference.OwnPrimaryKey:
+			expressions = append(expressions, clause.Eq{Column: foreignKey, Value: clause.Column{Table: table, Name: reference.PrimaryKey.DBName}})
+		case reference.PrimaryValue != "":
+			expressions = append(expressions, clause.Eq{Column: foreignKey, Value: reference.PrimaryValue})
+		case relation.JoinTable != nil:
+			expressions = append(expressions, clause.Eq{Column: foreignKey, Value: clause.Column{Table: alias, Name: reference.PrimaryKey.DBName}})
+		default:
+			// The foreign key of a belongs-to relation is in the table itself
+			expressions = append(expressions, clause.Eq{Column: clause.Column{Table: alias, Name: reference.PrimaryKey.DBName}, Value: clause.Column{Table: table, Name: reference.ForeignKey.DBName}})
+		}
+	}
+
+	return from, tables, expressions
+}
+
+// aggregate is an aggregate function on a column of a related table
+type aggregate struct {
+	sql    string
+	column any
+
+	// numeric is true if the function results in a number
+	numeric bool
+}
+
+// aggregateFunction returns the aggregate function if the name is one like 'count' or 'amount.sum', fields of the
+// schema with the same name as a function take precedence
+func aggregateFunction(fieldSchema *schema.Schema, alias string, name string) (aggregate, bool) {
+	if fieldSchema.LookUpField(name) != nil {
+		return aggregate{}, false
+	}
+
+	columnName, functionName := "", name
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		columnName, functionName = name[:index], name[index+1:]
+	}
+
+	functionName = strings.ToLower(functionName)
+
+	sql, ok := aggregateFunctions[functionName]
+	if !ok {
+		return aggregate{}, false
+	}
+
+	if columnName == "" {
+		// Only records can be counted without a column
+		if functionName != "count" {
+			return aggregate{}, false
+		}
+
+		return aggregate{sql: sql, column: clause.Expr{SQL: "*"}, numeric: true}, true
+	}
+
+	result := aggregate{sql: sql, column: relationColumn(fieldSchema, alias, columnName), numeric: functionName == "count"}
+
+	if field := fieldSchema.LookUpField(columnName); field != nil {
+		result.numeric = result.numeric || field.DataType == schema.Int || field.DataType == schema.Uint || field.DataType == schema.Float
+	}
+
+	return result, true
+}
+
+// numericValue converts strings to numbers where possible, since not every database converts them when they're
+// compared to the result of a subquery
+func numericValue(value any) any {
+	if isSlice(value) {
+		values := toList(value)
+		for index, element := range values {
+			values[index] = numericValue(element)
+		}
+
+		return values
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	if number, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return number
+	}
+
+	if number, err := strconv.ParseFloat(text, 64); err == nil {
+		return number
+	}
+
+	return value
+}
+
+// relationColumn creates a column of the related table, names of fields are converted to their column
+func relationColumn(fieldSchema *schema.Schema, alias string, name string) clause.Column {
+	if field := fieldSchema.LookUpField(name); field != nil && field.DBName != "" {
+		name = field.DBName
+	}
+
+	return clause.Column{Table: alias, Name: name}
+}
@@ -0,0 +1,201 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type relationCountry struct {
+	ID   int
+	Name string
+}
+
+type relationCustomer struct {
+	ID        int
+	Name      string
+	CountryID int
+	Country   relationCountry
+	Orders    []relationOrder `gorm:"foreignKey:CustomerID"`
+	Tags      []relationTag   `gorm:"many2many:relation_customer_tags"`
+}
+
+type relationOrder struct {
+	ID         int
+	Amount     int
+	CustomerID int
+	Customer   relationCustomer
+}
+
+type relationTag struct {
+	ID   int
+	Name string
+}
+
+func TestGormQonvert_RelationKeys_CreatesSubquery(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model    any
+		filter   map[string]any
+		expected string
+	}{
+		"belongs to": {
+			model:    &[]relationOrder{},
+			filter:   map[string]any{"customer.name": "~j%"},
+			expected: "SELECT * FROM `relation_orders` WHERE EXISTS (SELECT 1 FROM `relation_customers` `Customer` WHERE (`Customer`.`id` = `relation_orders`.`customer_id` AND `Customer`.`name` LIKE \"j%\"))",
+		},
+		"plain value": {
+			model:    &[]relationOrder{},
+			filter:   map[string]any{"customer.name": "jessica"},
+			expected: "SELECT * FROM `relation_orders` WHERE EXISTS (SELECT 1 FROM `relation_customers` `Customer` WHERE (`Customer`.`id` = `relation_orders`.`customer_id` AND `Customer`.`name` = \"jessica\"))",
+		},
+		"field name": {
+			model:    &[]relationOrder{},
+			filter:   map[string]any{"Customer.CountryID": ">1"},
+			expected: "SELECT * FROM `relation_orders` WHERE EXISTS (SELECT 1 FROM `relation_customers` `Customer` WHERE (`Customer`.`id` = `relation_orders`.`customer_id` AND `Customer`.`country_id` > \"1\"))",
+		},
+		"has many": {
+			model:    &[]relationCustomer{},
+			filter:   map[string]any{"orders.amount": ">=100"},
+			expected: "SELECT * FROM `relation_customers` WHERE EXISTS (SELECT 1 FROM `relation_orders` `Orders` WHERE (`Orders`.`customer_id` = `relation_customers`.`id` AND `Orders`.`amount` >= \"100\"))",
+		},
+		"multiple values": {
+			model:    &[]relationCustomer{},
+			filter:   map[string]any{"orders.amount": []string{"<10", ">100"}},
+			expected: "SELECT * FROM `relation_customers` WHERE (EXISTS (SELECT 1 FROM `relation_orders` `Orders` WHERE (`Orders`.`customer_id` = `relation_customers`.`id` AND `Orders`.`amount` < \"10\")) OR EXISTS (SELECT 1 FROM `relation_orders` `Orders` WHERE (`Orders`.`customer_id` = `relation_customers`.`id` AND `Orders`.`amount` > \"100\")))",
+		},
+		"many to many": {
+			model:    &[]relationCustomer{},
+			filter:   map[string]any{"tags.name": "vip"},
+			expected: "SELECT * FROM `relation_customers` WHERE EXISTS (SELECT 1 FROM `relation_tags` `Tags`, `relation_customer_tags` WHERE (`relation_customer_tags`.`relation_customer_id` = `relation_customers`.`id` AND `relation_customer_tags`.`relation_tag_id` = `Tags`.`id` AND `Tags`.`name` = \"vip\"))",
+		},
+		"nested relation": {
+			model:    &[]relationOrder{},
+			filter:   map[string]any{"customer.country.name": "NL"},
+			expected: "SELECT * FROM `relation_orders` WHERE EXISTS (SELECT 1 FROM `relation_customers` `Customer` WHERE (`Customer`.`id` = `relation_orders`.`customer_id` AND EXISTS (SELECT 1 FROM `relation_countries` `Customer__Country` WHERE (`Customer__Country`.`id` = `Customer`.`country_id` AND `Customer__Country`.`name` = \"NL\"))))",
+		},
+		"count": {
+			model:    &[]relationCustomer{},
+			filter:   map[string]any{"orders.count": ">5"},
+			expected: "SELECT * FROM `relation_customers` WHERE (SELECT COUNT(*) FROM `relation_orders` `Orders` WHERE `Orders`.`customer_id` = `relation_customers`.`id`) > 5",
+		},
+		"sum": {
+			model:    &[]relationCustomer{},
+			filter:   map[string]any{"orders.amount.sum": ">=1000"},
+			expected: "SELECT * FROM `relation_customers` WHERE (SELECT COALESCE(SUM(`Orders`.`amount`), 0) FROM `relation_orders` `Orders` WHERE `Orders`.`customer_id` = `relation_customers`.`id`) >= 1000",
+		},
+		"min of many to many": {
+			model:    &[]relationCustomer{},
+			filter:   map[string]any{"tags.name.min": "a"},
+			expected: "SELECT * FROM `relation_customers` WHERE (SELECT MIN(`Tags`.`name`) FROM `relation_tags` `Tags`, `relation_customer_tags` WHERE (`relation_customer_tags`.`relation_customer_id` = `relation_customers`.`id` AND `relation_customer_tags`.`relation_tag_id` = `Tags`.`id`)) = \"a\"",
+		},
+		"aggregate of nested relation": {
+			model:    &[]relationOrder{},
+			filter:   map[string]any{"customer.orders.id.max": []string{"<10", ">100"}},
+			expected: "SELECT * FROM `relation_orders` WHERE (EXISTS (SELECT 1 FROM `relation_customers` `Customer` WHERE (`Customer`.`id` = `relation_orders`.`customer_id` AND (SELECT MAX(`Customer__Orders`.`id`) FROM `relation_orders` `Customer__Orders` WHERE `Customer__Orders`.`customer_id` = `Customer`.`id`) < 10)) OR EXISTS (SELECT 1 FROM `relation_customers` `Customer` WHERE (`Customer`.`id` = `relation_orders`.`customer_id` AND (SELECT MAX(`Customer__Orders`.`id`) FROM `relation_orders` `Customer__Orders` WHERE `Customer__Orders`.`customer_id` = `Customer`.`id`) > 100)))",
+		},
+		"unknown relation": {
+			model:    &[]relationOrder{},
+			filter:   map[string]any{"supplier.name": "~j%"},
+			expected: "SELECT * FROM `relation_orders` WHERE `supplier`.`name` LIKE \"j%\"",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{LikePrefix: "~", GreaterThanPrefix: ">", GreaterOrEqualToPrefix: ">=", LessThanPrefix: "<"}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, RelationKeys()))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(testData.model)
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormQonvert_RelationKeys_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&relationCountry{}, &relationCustomer{}, &relationOrder{}, &relationTag{})
+	_ = db.Use(New(CharacterConfig{GreaterThanPrefix: ">"}, RelationKeys()))
+
+	_ = db.Create(&[]relationCustomer{
+		{ID: 1, Name: "jessica", Country: relationCountry{ID: 1, Name: "NL"}, Orders: []relationOrder{{ID: 1, Amount: 50}}},
+		{ID: 2, Name: "amy", Country: relationCountry{ID: 2, Name: "BE"}, Orders: []relationOrder{{ID: 2, Amount: 150}}},
+	}).Error
+
+	var actual []relationCustomer
+
+	// Act
+	err := db.Where(map[string]any{"country.name": "BE", "orders.amount": ">100"}).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []relationCustomer{{ID: 2, Name: "amy", CountryID: 2}}, actual)
+}
+
+func TestGormQonvert_RelationKeys_FiltersQueryOnAggregates(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   map[string]any
+		expected []relationCustomer
+	}{
+		"count": {
+			filter:   map[string]any{"orders.count": ">1"},
+			expected: []relationCustomer{{ID: 2, Name: "amy", CountryID: 2}},
+		},
+		"count without related records": {
+			filter:   map[string]any{"orders.count": "<1"},
+			expected: []relationCustomer{{ID: 3, Name: "boris", CountryID: 3}},
+		},
+		"sum": {
+			filter:   map[string]any{"orders.amount.sum": ">=100"},
+			expected: []relationCustomer{{ID: 2, Name: "amy", CountryID: 2}},
+		},
+		"max": {
+			filter:   map[string]any{"orders.amount.max": ">50"},
+			expected: []relationCustomer{{ID: 1, Name: "jessica", CountryID: 1}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&relationCountry{}, &relationCustomer{}, &relationOrder{}, &relationTag{})
+			_ = db.Use(New(CharacterConfig{GreaterThanPrefix: ">", GreaterOrEqualToPrefix: ">=", LessThanPrefix: "<"}, RelationKeys()))
+
+			_ = db.Create(&[]relationCustomer{
+				{ID: 1, Name: "jessica", Country: relationCountry{ID: 1, Name: "NL"}, Orders: []relationOrder{{ID: 1, Amount: 90}}},
+				{ID: 2, Name: "amy", Country: relationCountry{ID: 2, Name: "BE"}, Orders: []relationOrder{{ID: 2, Amount: 50}, {ID: 3, Amount: 50}}},
+				{ID: 3, Name: "boris", Country: relationCountry{ID: 3, Name: "DE"}},
+			}).Error
+
+			var actual []relationCustomer
+
+			// Act
+			err := db.Where(testData.filter).Find(&actual).Error
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
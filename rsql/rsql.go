@@ -0,0 +1,365 @@
+// Package rsql parses RSQL/FIQL filter strings like 'age=ge=30;name==jess*' into gormqonvert conditions.
+//
+// Constraints are combined using ';' (AND) and ',' (OR), where AND takes precedence. Parentheses can be used to group
+// constraints. The supported operators are '==', '!=', '=gt=' or '>', '=ge=' or '>=', '=lt=' or '<', '=le=' or '<=',
+// '=like=', '=notlike=', '=in=' and '=out='. A '*' in the value of '==' and '!=' turns them into (NOT) LIKE-checks.
+// '*' is the only wildcard, so '%' and '_' match literally.
+//
+// Selectors are used as column names. Filters from users should be parsed with AllowedSelectors(), since the
+// conditions are added to queries by gormqonvert.Scope(), which skips the limits and other checks of the plugin.
+package rsql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+	"github.com/survivorbat/gorm-query-convert/internal/lexer"
+)
+
+// operators maps RSQL comparison operators to the operators of the plugin
+var operators = map[string]gormqonvert.Operator{
+	"==":        gormqonvert.OperatorEqual,
+	"!=":        gormqonvert.OperatorNotEqual,
+	"=gt=":      gormqonvert.OperatorGreaterThan,
+	">":         gormqonvert.OperatorGreaterThan,
+	"=ge=":      gormqonvert.OperatorGreaterOrEqualTo,
+	">=":        gormqonvert.OperatorGreaterOrEqualTo,
+	"=lt=":      gormqonvert.OperatorLessThan,
+	"<":         gormqonvert.OperatorLessThan,
+	"=le=":      gormqonvert.OperatorLessOrEqualTo,
+	"<=":        gormqonvert.OperatorLessOrEqualTo,
+	"=like=":    gormqonvert.OperatorLike,
+	"=notlike=": gormqonvert.OperatorNotLike,
+	"=in=":      gormqonvert.OperatorIn,
+	"=out=":     gormqonvert.OperatorNotIn,
+}
+
+// reservedCharacters can't be part of unquoted selectors and values
+const reservedCharacters = "\"'();,=!~<> \t\r\n"
+
+// ErrUnknownSelector is returned if a selector in the filter is not one of the AllowedSelectors()
+var ErrUnknownSelector = errors.New("unknown selector")
+
+// Option changes how filters are parsed
+type Option func(*parser)
+
+// AllowedSelectors makes it so that only the given selectors can be used in the filter, other selectors result in
+// an ErrUnknownSelector. All selectors are allowed if this option is not used.
+func AllowedSelectors(selectors ...string) Option {
+	return func(p *parser) {
+		p.allowedSelectors = make(map[string]struct{}, len(selectors))
+		for _, selector := range selectors {
+			p.allowedSelectors[selector] = struct{}{}
+		}
+	}
+}
+
+// SyntaxError is returned if a filter could not be parsed, Position is the byte offset in the filter at which
+// the problem was found.
+type SyntaxError = lexer.SyntaxError
+
+// Parse turns an RSQL filter into a single condition, that can be added to a query
+// using db.Scopes(gormqonvert.Scope(condition)).
+func Parse(filter string, opts ...Option) (gormqonvert.Condition, error) {
+	p := &parser{input: filter}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	condition, err := p.parseOr()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	p.skipWhitespace()
+
+	if !p.done() {
+		return gormqonvert.Condition{}, p.errorf("unexpected '%c'", p.input[p.position])
+	}
+
+	return condition, nil
+}
+
+type parser struct {
+	input    string
+	position int
+
+	// allowedSelectors are the selectors that can be used, nil if all of them can
+	allowedSelectors map[string]struct{}
+}
+
+// parseOr parses constraints separated by ','
+func (p *parser) parseOr() (gormqonvert.Condition, error) {
+	return p.parseList(',', p.parseAnd, gormqonvert.Or)
+}
+
+// parseAnd parses constraints separated by ';'
+func (p *parser) parseAnd() (gormqonvert.Condition, error) {
+	return p.parseList(';', p.parseConstraint, gormqonvert.And)
+}
+
+// parseList parses one or more elements separated by the separator and combines them if there are multiple
+func (p *parser) parseList(separator byte, element func() (gormqonvert.Condition, error), combine func(...gormqonvert.Condition) gormqonvert.Condition) (gormqonvert.Condition, error) {
+	first, err := element()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	conditions := []gormqonvert.Condition{first}
+
+	for p.skipWhitespace(); p.peek() == separator; p.skipWhitespace() {
+		p.position++
+
+		next, err := element()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		conditions = append(conditions, next)
+	}
+
+	if len(conditions) == 1 {
+		return first, nil
+	}
+
+	return combine(conditions...), nil
+}
+
+// parseConstraint parses either a group between parentheses or a comparison
+func (p *parser) parseConstraint() (gormqonvert.Condition, error) {
+	p.skipWhitespace()
+
+	if p.peek() != '(' {
+		return p.parseComparison()
+	}
+
+	p.position++
+
+	condition, err := p.parseOr()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	if err := p.expect(')'); err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	return condition, nil
+}
+
+// parseComparison parses a selector, an operator and its arguments
+func (p *parser) parseComparison() (gormqonvert.Condition, error) {
+	selectorPosition := p.position
+
+	selector := p.readUnreserved()
+	if selector == "" {
+		return gormqonvert.Condition{}, p.errorf("expected selector")
+	}
+
+	if _, ok := p.allowedSelectors[selector]; p.allowedSelectors != nil && !ok {
+		return gormqonvert.Condition{}, fmt.Errorf("%w '%s' at position %d", ErrUnknownSelector, selector, selectorPosition)
+	}
+
+	p.skipWhitespace()
+
+	operatorPosition := p.position
+
+	operator, ok := operators[p.readOperator()]
+	if !ok {
+		p.position = operatorPosition
+		return gormqonvert.Condition{}, p.errorf("expected operator")
+	}
+
+	p.skipWhitespace()
+
+	if operator == gormqonvert.OperatorIn || operator == gormqonvert.OperatorNotIn {
+		values, err := p.parseArguments()
+		if err != nil {
+			return gormqonvert.Condition{}, err
+		}
+
+		return gormqonvert.Condition{Column: selector, Operator: operator, Value: values}, nil
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return gormqonvert.Condition{}, err
+	}
+
+	return comparison(selector, operator, value), nil
+}
+
+// comparison creates the condition of a single comparison, values with wildcards are converted into LIKE-patterns
+// in which only '*' is a wildcard
+func comparison(selector string, operator gormqonvert.Operator, value string) gormqonvert.Condition {
+	switch operator {
+	case gormqonvert.OperatorEqual, gormqonvert.OperatorNotEqual:
+		if !strings.Contains(value, "*") {
+			break
+		}
+
+		if operator == gormqonvert.OperatorEqual {
+			operator = gormqonvert.OperatorLike
+		} else {
+			operator = gormqonvert.OperatorNotLike
+		}
+
+		fallthrough
+	case gormqonvert.OperatorLike, gormqonvert.OperatorNotLike:
+		return gormqonvert.Condition{Column: selector, Operator: operator, Value: gormqonvert.WildcardPattern(value, "*"), Escaped: true}
+	}
+
+	return gormqonvert.Condition{Column: selector, Operator: operator, Value: value}
+}
+
+// parseArguments parses a list of values between parentheses, or a single value
+func (p *parser) parseArguments() ([]any, error) {
+	if p.peek() != '(' {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return []any{value}, nil
+	}
+
+	p.position++
+
+	var values []any
+
+	for {
+		p.skipWhitespace()
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		p.skipWhitespace()
+
+		if p.peek() != ',' {
+			break
+		}
+
+		p.position++
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// parseValue parses a quoted or unquoted value
+func (p *parser) parseValue() (string, error) {
+	quote := p.peek()
+	if quote != '"' && quote != '\'' {
+		value := p.readUnreserved()
+		if value == "" {
+			return "", p.errorf("expected value")
+		}
+
+		return value, nil
+	}
+
+	start := p.position
+	p.position++
+
+	var result strings.Builder
+
+	for !p.done() {
+		character := p.input[p.position]
+		p.position++
+
+		switch character {
+		case quote:
+			return result.String(), nil
+		case '\\':
+			if p.done() {
+				continue
+			}
+
+			character = p.input[p.position]
+			p.position++
+		}
+
+		result.WriteByte(character)
+	}
+
+	p.position = start
+
+	return "", p.errorf("unterminated string")
+}
+
+// readOperator reads a comparison operator without checking whether it exists
+func (p *parser) readOperator() string {
+	start := p.position
+
+	switch {
+	case strings.HasPrefix(p.input[p.position:], "=="), strings.HasPrefix(p.input[p.position:], "!="),
+		strings.HasPrefix(p.input[p.position:], "<="), strings.HasPrefix(p.input[p.position:], ">="):
+		p.position += 2
+	case p.peek() == '<' || p.peek() == '>':
+		p.position++
+	case p.peek() == '=':
+		end := strings.IndexByte(p.input[p.position+1:], '=')
+		if end < 0 {
+			return ""
+		}
+
+		p.position += end + 2
+	}
+
+	return p.input[start:p.position]
+}
+
+// readUnreserved reads characters until a reserved character is found
+func (p *parser) readUnreserved() string {
+	start := p.position
+	for !p.done() && !strings.ContainsRune(reservedCharacters, rune(p.input[p.position])) {
+		p.position++
+	}
+
+	return p.input[start:p.position]
+}
+
+// expect skips whitespace and returns an error if the next character is not the given one
+func (p *parser) expect(character byte) error {
+	p.skipWhitespace()
+
+	if p.peek() != character {
+		return p.errorf("expected '%c'", character)
+	}
+
+	p.position++
+
+	return nil
+}
+
+func (p *parser) skipWhitespace() {
+	for !p.done() && lexer.IsWhitespace(p.input[p.position]) {
+		p.position++
+	}
+}
+
+// peek returns the current character, or 0 if the end was reached
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+
+	return p.input[p.position]
+}
+
+func (p *parser) done() bool {
+	return p.position >= len(p.input)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return lexer.Errorf(p.position, format, args...)
+}
@@ -0,0 +1,265 @@
+package rsql
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	gormqonvert "github.com/survivorbat/gorm-query-convert"
+)
+
+func TestParse_ReturnsExpectedCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   string
+		expected gormqonvert.Condition
+	}{
+		"equal": {
+			filter:   "name==jessica",
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "jessica"},
+		},
+		"wildcard": {
+			filter:   "name==jess*",
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorLike, Value: "jess%", Escaped: true},
+		},
+		"negated wildcard": {
+			filter:   "name!=*ss*",
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorNotLike, Value: "%ss%", Escaped: true},
+		},
+		"wildcards of like are escaped": {
+			filter:   "name==a_%*",
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorLike, Value: "a!_!%%", Escaped: true},
+		},
+		"like operator": {
+			filter:   "name=like=*_b",
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorLike, Value: "%!_b", Escaped: true},
+		},
+		"named operator": {
+			filter:   "age=ge=30",
+			expected: gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterOrEqualTo, Value: "30"},
+		},
+		"symbol operator": {
+			filter:   "age<30",
+			expected: gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorLessThan, Value: "30"},
+		},
+		"quoted value": {
+			filter:   `name=="jess, \"the\" (best);"`,
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: `jess, "the" (best);`},
+		},
+		"single quoted value": {
+			filter:   `name=='amy'`,
+			expected: gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "amy"},
+		},
+		"in": {
+			filter:   "status=in=(a, 'b,c')",
+			expected: gormqonvert.Condition{Column: "status", Operator: gormqonvert.OperatorIn, Value: []any{"a", "b,c"}},
+		},
+		"out with single value": {
+			filter:   "status=out=a",
+			expected: gormqonvert.Condition{Column: "status", Operator: gormqonvert.OperatorNotIn, Value: []any{"a"}},
+		},
+		"and takes precedence": {
+			filter: "age=ge=30;name==jess*,status=in=(a,b)",
+			expected: gormqonvert.Or(
+				gormqonvert.And(
+					gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterOrEqualTo, Value: "30"},
+					gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorLike, Value: "jess%", Escaped: true},
+				),
+				gormqonvert.Condition{Column: "status", Operator: gormqonvert.OperatorIn, Value: []any{"a", "b"}},
+			),
+		},
+		"parentheses": {
+			filter: " age=ge=30 ; ( name==amy , name==boris ) ",
+			expected: gormqonvert.And(
+				gormqonvert.Condition{Column: "age", Operator: gormqonvert.OperatorGreaterOrEqualTo, Value: "30"},
+				gormqonvert.Or(
+					gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "amy"},
+					gormqonvert.Condition{Column: "name", Operator: gormqonvert.OperatorEqual, Value: "boris"},
+				),
+			),
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := Parse(testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParse_ReturnsSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   string
+		expected *SyntaxError
+	}{
+		"empty": {
+			filter:   "",
+			expected: &SyntaxError{Position: 0, Message: "expected selector"},
+		},
+		"missing operator": {
+			filter:   "age",
+			expected: &SyntaxError{Position: 3, Message: "expected operator"},
+		},
+		"unknown operator": {
+			filter:   "age=between=1",
+			expected: &SyntaxError{Position: 3, Message: "expected operator"},
+		},
+		"missing value": {
+			filter:   "age=ge=",
+			expected: &SyntaxError{Position: 7, Message: "expected value"},
+		},
+		"unterminated string": {
+			filter:   `name=="jess`,
+			expected: &SyntaxError{Position: 6, Message: "unterminated string"},
+		},
+		"unclosed parentheses": {
+			filter:   "(age=ge=30",
+			expected: &SyntaxError{Position: 10, Message: "expected ')'"},
+		},
+		"unclosed list": {
+			filter:   "age=in=(1,2",
+			expected: &SyntaxError{Position: 11, Message: "expected ')'"},
+		},
+		"trailing characters": {
+			filter:   "age=ge=30)",
+			expected: &SyntaxError{Position: 9, Message: "unexpected ')'"},
+		},
+		"trailing separator": {
+			filter:   "age=ge=30;",
+			expected: &SyntaxError{Position: 10, Message: "expected selector"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := Parse(testData.filter)
+
+			// Assert
+			assert.Equal(t, testData.expected, err)
+		})
+	}
+}
+
+func TestParse_AllowedSelectors_RejectsOtherSelectors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter string
+		error  string
+	}{
+		"allowed selectors": {
+			filter: "age=ge=30;name==jess*",
+		},
+		"other selector": {
+			filter: "age=ge=30;password_hash=like=a*",
+			error:  "unknown selector 'password_hash' at position 10",
+		},
+		"other selector in a group": {
+			filter: "name==jess,(age=ge=30;id==1)",
+			error:  "unknown selector 'id' at position 22",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := Parse(testData.filter, AllowedSelectors("age", "name"))
+
+			// Assert
+			if testData.error == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrUnknownSelector)
+			assert.EqualError(t, err, testData.error)
+		})
+	}
+}
+
+func TestSyntaxError_Error_ReturnsExpectedMessage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	err := &SyntaxError{Position: 3, Message: "expected operator"}
+
+	// Act
+	result := err.Error()
+
+	// Assert
+	assert.Equal(t, "expected operator at position 3", result)
+}
+
+func TestParse_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectA struct {
+		Name   string
+		Age    int
+		Status string
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectA{})
+
+	existing := []ObjectA{
+		{Name: "jessica", Age: 29, Status: "c"},
+		{Name: "jess", Age: 31, Status: "c"},
+		{Name: "amy", Age: 30, Status: "a"},
+		{Name: "boris", Age: 31, Status: "c"},
+	}
+	if err := db.CreateInBatches(existing, 10).Error; err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	condition, err := Parse("age=ge=30;name==jess*,status=in=(a,b)")
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectA
+	err = db.Where("age < ?", 40).Scopes(gormqonvert.Scope(condition)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectA{{Name: "jess", Age: 31, Status: "c"}, {Name: "amy", Age: 30, Status: "a"}}, actual)
+}
+
+func TestParse_FiltersQueryWithLiteralWildcards(t *testing.T) {
+	t.Parallel()
+
+	type ObjectB struct {
+		Name string
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectB{})
+	_ = db.Create([]ObjectB{{Name: "abc"}, {Name: "a_c"}, {Name: "a%c"}}).Error
+
+	condition, err := Parse("name==a_*")
+	assert.NoError(t, err)
+
+	// Act
+	var actual []ObjectB
+	err = db.Scopes(gormqonvert.Scope(condition)).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectB{{Name: "a_c"}}, actual)
+}
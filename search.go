@@ -0,0 +1,36 @@
+package gormqonvert
+
+import (
+	"fmt"
+)
+
+// searchKey contains the columns that a virtual search key looks through
+type searchKey struct {
+	columns    []string
+	ignoreCase bool
+}
+
+// condition creates an Or-condition over all columns for every value, all of which have to match
+func (s searchKey) condition(values []any) Condition {
+	terms := make([]Condition, len(values))
+
+	for index, value := range values {
+		alternatives := make([]Condition, len(s.columns))
+		for columnIndex, column := range s.columns {
+			alternatives[columnIndex] = Condition{
+				Column:     column,
+				Operator:   OperatorContains,
+				Value:      fmt.Sprint(value),
+				IgnoreCase: s.ignoreCase,
+			}
+		}
+
+		terms[index] = Or(alternatives...)
+	}
+
+	if len(terms) == 1 {
+		return terms[0]
+	}
+
+	return And(terms...)
+}
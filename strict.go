@@ -0,0 +1,175 @@
+package gormqonvert
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrUnknownColumn is used if Strict() is used and a query filters on a column that is not in the model
+	ErrUnknownColumn = errors.New("unknown column")
+
+	// ErrInvalidValue is used if Strict() is used and a value does not fit its column or operator
+	ErrInvalidValue = errors.New("invalid value")
+
+	// ErrOperatorNotAllowed is used if Strict() is used and an operator is unknown or can't be used on a column
+	ErrOperatorNotAllowed = errors.New("operator not allowed")
+
+	// ErrUnsupportedClause is used if Strict() is used and the WHERE-clause of a query can't be converted
+	ErrUnsupportedClause = errors.New("unsupported clause")
+)
+
+// patternOperators are the operators that can only be used on text
+var patternOperators = map[Operator]bool{
+	OperatorLike:       true,
+	OperatorNotLike:    true,
+	OperatorContains:   true,
+	OperatorStartsWith: true,
+	OperatorEndsWith:   true,
+	OperatorRegex:      true,
+}
+
+// FilterError is added to a query if Strict() is used and part of the filter can't be converted, use errors.Is()
+// to check which of the errors it is and errors.As() to find the column, value and operator.
+type FilterError struct {
+	// Err is one of ErrUnknownColumn, ErrInvalidValue, ErrOperatorNotAllowed or ErrUnsupportedClause
+	Err error
+
+	// Column is the column or key in the filter
+	Column string
+
+	// Value is the value in the filter, without the prefix of its operator
+	Value any
+
+	// Operator is the operator of the condition, which can be empty if it's unknown
+	Operator Operator
+}
+
+func (e *FilterError) Error() string {
+	if e.Operator == "" {
+		return fmt.Sprintf("%s: column '%s', value '%v'", e.Err, e.Column, e.Value)
+	}
+
+	return fmt.Sprintf("%s: column '%s', operator '%s', value '%v'", e.Err, e.Column, e.Operator, e.Value)
+}
+
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
+
+// reportStrict adds a FilterError to the query if Strict() is used
+func (d *gormQonvert) reportStrict(db *gorm.DB, err error, condition Condition) {
+	if !d.strict {
+		return
+	}
+
+	_ = db.AddError(&FilterError{Err: err, Column: condition.Column, Value: condition.Value, Operator: condition.Operator})
+}
+
+// checkStrict checks the column, operator and values of the condition against the model if Strict() is used, the
+// result is false if the condition was rejected. Searched is true if the condition was created by a search key, only
+// its columns are checked since a search looks through columns of any type.
+func (d *gormQonvert) checkStrict(db *gorm.DB, condition Condition, searched bool) bool {
+	if !d.strict || db.Statement.Schema == nil {
+		return true
+	}
+
+	if _, ok := d.virtualFields[condition.Column]; ok {
+		return true
+	}
+
+	field, known := d.lookUpField(db.Statement.Schema, condition.Column)
+	if !known {
+		d.reportStrict(db, ErrUnknownColumn, condition)
+		return false
+	}
+
+	// Aggregates and columns of other tables can't be checked
+	if field == nil {
+		return true
+	}
+
+	// Search keys look through columns of any type, the text of the column is searched for the value
+	if searched {
+		return true
+	}
+
+	if patternOperators[condition.Operator] && field.DataType != schema.String {
+		d.reportStrict(db, ErrOperatorNotAllowed, condition)
+		return false
+	}
+
+	values := toList(condition.Value)
+	if condition.Operator == OperatorBetween && len(values) != 2 {
+		d.reportStrict(db, ErrInvalidValue, condition)
+		return false
+	}
+
+	if condition.Operator == OperatorIsNull {
+		return true
+	}
+
+	for _, value := range values {
+		if text, ok := value.(string); ok && !fitsDataType(field.DataType, text) {
+			d.reportStrict(db, ErrInvalidValue, condition)
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookUpField finds the field of a column. The field is nil if the column is known but not a field, like aggregates
+// of relations or columns prefixed with another table.
+func (d *gormQonvert) lookUpField(modelSchema *schema.Schema, name string) (*schema.Field, bool) {
+	if d.relationKeys {
+		if relation, rest, ok := findRelation(modelSchema, name); ok {
+			return lookUpRelationField(relation, rest)
+		}
+	}
+
+	if field := modelSchema.LookUpField(name); field != nil && field.DBName != "" {
+		return field, true
+	}
+
+	return nil, strings.Contains(name, ".")
+}
+
+// lookUpRelationField finds the field of a column in the related schema, which can be an aggregate or a column of
+// a nested relation
+func lookUpRelationField(relation *schema.Relationship, name string) (*schema.Field, bool) {
+	if nested, rest, ok := findRelation(relation.FieldSchema, name); ok {
+		return lookUpRelationField(nested, rest)
+	}
+
+	if field := relation.FieldSchema.LookUpField(name); field != nil && field.DBName != "" {
+		return field, true
+	}
+
+	_, isAggregate := aggregateFunction(relation.FieldSchema, "", name)
+
+	return nil, isAggregate
+}
+
+// fitsDataType returns true if the text can be converted to the data type, types that are not checked always fit
+func fitsDataType(dataType schema.DataType, text string) bool {
+	var err error
+
+	switch dataType {
+	case schema.Bool:
+		_, err = strconv.ParseBool(text)
+	case schema.Int:
+		_, err = strconv.ParseInt(text, 10, 64)
+	case schema.Uint:
+		_, err = strconv.ParseUint(text, 10, 64)
+	case schema.Float:
+		_, err = strconv.ParseFloat(text, 64)
+	}
+
+	return err == nil
+}
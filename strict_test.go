@@ -0,0 +1,117 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGormQonvert_Strict_AddsFilterError(t *testing.T) {
+	t.Parallel()
+
+	type ObjectI struct {
+		Name   string
+		Age    int
+		Active bool
+	}
+
+	tests := map[string]struct {
+		options  []Option
+		filter   map[string]any
+		expected error
+	}{
+		"valid filter": {
+			filter: map[string]any{"name": "~j%", "age": []string{">3", "1"}, "active": "true"},
+		},
+		"unknown column": {
+			filter:   map[string]any{"nmae": "~j%"},
+			expected: &FilterError{Err: ErrUnknownColumn, Column: "nmae", Value: "j%", Operator: OperatorLike},
+		},
+		"unknown column without operator": {
+			filter:   map[string]any{"nmae": "jessica"},
+			expected: &FilterError{Err: ErrUnknownColumn, Column: "nmae", Value: "jessica", Operator: OperatorEqual},
+		},
+		"unknown column in list": {
+			filter:   map[string]any{"nmae": []string{"jessica", "amy"}},
+			expected: &FilterError{Err: ErrUnknownColumn, Column: "nmae", Value: []any{"jessica", "amy"}, Operator: OperatorIn},
+		},
+		"invalid number": {
+			filter:   map[string]any{"age": ">abc"},
+			expected: &FilterError{Err: ErrInvalidValue, Column: "age", Value: "abc", Operator: OperatorGreaterThan},
+		},
+		"invalid number without operator": {
+			filter:   map[string]any{"age": "abc"},
+			expected: &FilterError{Err: ErrInvalidValue, Column: "age", Value: "abc", Operator: OperatorEqual},
+		},
+		"invalid boolean": {
+			filter:   map[string]any{"active": []string{"yes", "!=false"}},
+			expected: &FilterError{Err: ErrInvalidValue, Column: "active", Value: "yes", Operator: OperatorEqual},
+		},
+		"invalid value for key": {
+			options:  []Option{DjangoKeys()},
+			filter:   map[string]any{"age__range": "1,2,3"},
+			expected: &FilterError{Err: ErrInvalidValue, Column: "age", Value: "1,2,3", Operator: OperatorBetween},
+		},
+		"search key on number": {
+			options: []Option{WithSearchKey("q", "name", "age")},
+			filter:  map[string]any{"q": "jes"},
+		},
+		"search key on unknown column": {
+			options:  []Option{WithSearchKey("q", "name", "nmae")},
+			filter:   map[string]any{"q": "jes"},
+			expected: &FilterError{Err: ErrUnknownColumn, Column: "nmae", Value: "jes", Operator: OperatorContains},
+		},
+		"pattern on number": {
+			filter:   map[string]any{"age": "~1%"},
+			expected: &FilterError{Err: ErrOperatorNotAllowed, Column: "age", Value: "1%", Operator: OperatorLike},
+		},
+		"unknown operator in key": {
+			options:  []Option{BracketKeys()},
+			filter:   map[string]any{"age[foo]": "1"},
+			expected: &FilterError{Err: ErrOperatorNotAllowed, Column: "age", Value: "1", Operator: "foo"},
+		},
+		"virtual field": {
+			options: []Option{WithVirtualField("years", VirtualField{SQL: "age"})},
+			filter:  map[string]any{"years": "~1%"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{LikePrefix: "~", GreaterThanPrefix: ">", NotEqualToPrefix: "!="}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectI{})
+			_ = db.Use(New(config, append(testData.options, Strict())...))
+
+			// Act
+			err := db.Where(testData.filter).Find(&[]ObjectI{}).Error
+
+			// Assert
+			if testData.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, testData.expected, err)
+		})
+	}
+}
+
+func TestFilterError_Error_ReturnsExpectedMessage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	err := &FilterError{Err: ErrInvalidValue, Column: "age", Value: "abc", Operator: OperatorGreaterThan}
+
+	// Act
+	result := err.Error()
+
+	// Assert
+	assert.Equal(t, "invalid value: column 'age', operator '>', value 'abc'", result)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
@@ -0,0 +1,155 @@
+package gormqonvert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// structTag is the name of the tag that FromStruct() reads
+const structTag = "qonvert"
+
+// FromStruct turns a struct with tags like `qonvert:"age,gte"` into conditions, the tag holds the column and
+// optionally the operator, which is an equal-check if left out. The operators are the lookups of DjangoKeys(), like
+// 'gte', 'icontains' or 'in', and the operators of BracketKeys(), like 'ne' or 'nlike'. Fields with 'in' or 'range'
+// take a list, or a string that is split on commas.
+//
+// Fields without a tag or with the tag `qonvert:"-"` are left out, as are zero values like nil pointers, "" or 0 and
+// empty lists. Use a pointer like *int to filter on a zero value. Fields of embedded structs are used as if they were
+// part of the struct.
+func FromStruct(filter any) ([]Condition, error) {
+	value := reflect.ValueOf(filter)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("filter must be a struct, got '%T'", filter)
+	}
+
+	return structConditions(value, []Condition{})
+}
+
+// StructScope is like Scope, but uses the conditions of FromStruct(). Errors are added to the query.
+func StructScope(filter any) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		conditions, err := FromStruct(filter)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		return Scope(conditions...)(db)
+	}
+}
+
+// structConditions adds the conditions of the fields of the struct to the result
+func structConditions(value reflect.Value, result []Condition) ([]Condition, error) {
+	for index := 0; index < value.NumField(); index++ {
+		field := value.Type().Field(index)
+
+		tag, tagged := field.Tag.Lookup(structTag)
+
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			var err error
+			if result, err = structConditions(value.Field(index), result); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if !tagged || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		template, err := tagCondition(field.Name, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		// Zero values can't be told apart from fields that weren't set, pointers can be used to filter on them
+		if value.Field(index).IsZero() {
+			continue
+		}
+
+		fieldValue := value.Field(index)
+		for (fieldValue.Kind() == reflect.Pointer || fieldValue.Kind() == reflect.Interface) && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
+		if isNilField(fieldValue) {
+			continue
+		}
+
+		values, ok := filterValues(fieldValue.Interface())
+		if !ok {
+			continue
+		}
+
+		condition, ok := fieldCondition(template, fieldValue.Interface(), values)
+		if !ok {
+			return nil, fmt.Errorf("invalid value '%v' for operator '%s' in field '%s'", fieldValue.Interface(), template.Operator, field.Name)
+		}
+
+		result = append(result, condition)
+	}
+
+	return result, nil
+}
+
+// fieldCondition creates the condition of a field from the template of its tag. Lists of IN and BETWEEN operators
+// are used as they are, only a single string is split on commas like the value of a key.
+func fieldCondition(template Condition, fieldValue any, values []any) (Condition, bool) {
+	switch template.Operator {
+	case OperatorIn, OperatorNotIn, OperatorBetween:
+		if !isSlice(fieldValue) {
+			break
+		}
+
+		if template.Operator == OperatorBetween && len(values) != 2 {
+			return Condition{}, false
+		}
+
+		template.Value = values
+
+		return template, true
+	}
+
+	return keyCondition(template, values)
+}
+
+// tagCondition turns a tag like 'age,gte' into a condition without a value
+func tagCondition(fieldName string, tag string) (Condition, error) {
+	column, operatorName, _ := strings.Cut(tag, ",")
+	if column == "" {
+		return Condition{}, fmt.Errorf("missing column in tag of field '%s'", fieldName)
+	}
+
+	if operatorName == "" {
+		return Condition{Column: column, Operator: OperatorEqual}, nil
+	}
+
+	if template, ok := djangoLookups[operatorName]; ok {
+		template.Column = column
+		return template, nil
+	}
+
+	if operator, ok := bracketOperators[operatorName]; ok {
+		return Condition{Column: column, Operator: operator}, nil
+	}
+
+	return Condition{}, fmt.Errorf("unknown operator '%s' in tag of field '%s'", operatorName, fieldName)
+}
+
+// isNilField returns true if the field is a nil pointer, interface, slice or map
+func isNilField(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return value.IsNil()
+	}
+
+	return false
+}
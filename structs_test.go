@@ -0,0 +1,194 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type testPaging struct {
+	Page int
+	Sort string `qonvert:"-"`
+}
+
+type testUserFilter struct {
+	testPaging
+
+	MinAge   *int     `qonvert:"age,gte"`
+	MaxAge   *int     `qonvert:"age,lt"`
+	Name     *string  `qonvert:"name,icontains"`
+	Statuses []string `qonvert:"status,in"`
+	Deleted  *bool    `qonvert:"deleted_at,isnull"`
+	Country  string   `qonvert:"country"`
+	Internal string
+}
+
+func TestFromStruct_ReturnsExpectedConditions(t *testing.T) {
+	t.Parallel()
+
+	age := 30
+	zero := 0
+	name := "jes"
+	deleted := false
+
+	tests := map[string]struct {
+		filter   any
+		expected []Condition
+	}{
+		"nil fields are left out": {
+			filter: testUserFilter{Country: "NL"},
+			expected: []Condition{
+				{Column: "country", Operator: OperatorEqual, Value: "NL"},
+			},
+		},
+		"all fields": {
+			filter: &testUserFilter{
+				testPaging: testPaging{Page: 2, Sort: "name"},
+				MinAge:     &age,
+				MaxAge:     &age,
+				Name:       &name,
+				Statuses:   []string{"active", "new"},
+				Deleted:    &deleted,
+				Country:    "NL",
+				Internal:   "secret",
+			},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: 30},
+				{Column: "age", Operator: OperatorLessThan, Value: 30},
+				{Column: "name", Operator: OperatorContains, Value: "jes", IgnoreCase: true},
+				{Column: "status", Operator: OperatorIn, Value: []any{"active", "new"}},
+				{Column: "deleted_at", Operator: OperatorIsNull, Value: false},
+				{Column: "country", Operator: OperatorEqual, Value: "NL"},
+			},
+		},
+		"empty lists are left out": {
+			filter:   testUserFilter{Statuses: []string{}},
+			expected: []Condition{},
+		},
+		"zero values are left out": {
+			filter:   testUserFilter{Country: "", Internal: "secret"},
+			expected: []Condition{},
+		},
+		"pointers to zero values": {
+			filter: testUserFilter{MinAge: &zero},
+			expected: []Condition{
+				{Column: "age", Operator: OperatorGreaterOrEqualTo, Value: 0},
+			},
+		},
+		"commas in list values": {
+			filter: struct {
+				Names []string `qonvert:"name,in"`
+			}{Names: []string{"Doe, John"}},
+			expected: []Condition{
+				{Column: "name", Operator: OperatorIn, Value: []any{"Doe, John"}},
+			},
+		},
+		"comma separated string": {
+			filter: struct {
+				Names string `qonvert:"name,in"`
+			}{Names: "Jane,John"},
+			expected: []Condition{
+				{Column: "name", Operator: OperatorIn, Value: []any{"Jane", "John"}},
+			},
+		},
+		"embedded fields": {
+			filter: struct {
+				testUserFilter
+				Email string `qonvert:"email,ne"`
+			}{Email: "jessica@example.com"},
+			expected: []Condition{
+				{Column: "email", Operator: OperatorNotEqual, Value: "jessica@example.com"},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := FromStruct(testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestFromStruct_ReturnsErrorOnInvalidFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   any
+		expected string
+	}{
+		"not a struct": {
+			filter:   map[string]any{"age": 30},
+			expected: "filter must be a struct, got 'map[string]interface {}'",
+		},
+		"unknown operator": {
+			filter: struct {
+				Age int `qonvert:"age,bigger"`
+			}{},
+			expected: "unknown operator 'bigger' in tag of field 'Age'",
+		},
+		"missing column": {
+			filter: struct {
+				Age int `qonvert:",gte"`
+			}{},
+			expected: "missing column in tag of field 'Age'",
+		},
+		"invalid value": {
+			filter: struct {
+				Ages []int `qonvert:"age,range"`
+			}{Ages: []int{1, 2, 3}},
+			expected: "invalid value '[1 2 3]' for operator 'BETWEEN' in field 'Ages'",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := FromStruct(testData.filter)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.EqualError(t, err, testData.expected)
+		})
+	}
+}
+
+func TestStructScope_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectQ struct {
+		Name string
+		Age  int
+	}
+
+	age := 30
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	filter := struct {
+		MinAge *int    `qonvert:"age,gte"`
+		Name   *string `qonvert:"name,contains"`
+	}{MinAge: &age}
+
+	// Act
+	result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(StructScope(filter)).Find(&[]ObjectQ{})
+	})
+
+	err := db.Scopes(StructScope(42)).Find(&[]ObjectQ{}).Error
+
+	// Assert
+	assert.Equal(t, "SELECT * FROM `object_qs` WHERE `object_qs`.`age` >= 30", result)
+	assert.EqualError(t, err, "filter must be a struct, got 'int'")
+}
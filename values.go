@@ -0,0 +1,107 @@
+package gormqonvert
+
+import (
+	"net/url"
+)
+
+// defaultReservedKeys are query parameters that are generally used for other purposes than filtering
+var defaultReservedKeys = []string{"page", "sort"}
+
+// ValuesOption can be given to the FromValues() method to tweak its behaviour
+type ValuesOption func(config *valuesConfig)
+
+type valuesConfig struct {
+	reservedKeys map[string]bool
+	allowedKeys  map[string]bool
+	columnNames  map[string]string
+}
+
+// ReservedKeys replaces the default reserved keys 'page' and 'sort', reserved keys are never added to the filter.
+func ReservedKeys(keys ...string) ValuesOption {
+	return func(config *valuesConfig) {
+		config.reservedKeys = toSet(keys)
+	}
+}
+
+// AllowedKeys makes it so that only the given query parameters are added to the filter, this is checked before
+// parameters are mapped to their column names.
+func AllowedKeys(keys ...string) ValuesOption {
+	return func(config *valuesConfig) {
+		config.allowedKeys = toSet(keys)
+	}
+}
+
+// ColumnNames maps public query parameter names to the columns they filter on, parameters that are not in the
+// map are used as-is.
+func ColumnNames(columns map[string]string) ValuesOption {
+	return func(config *valuesConfig) {
+		config.columnNames = columns
+	}
+}
+
+// FromValues turns query parameters like the ones from r.URL.Query() into a filter map that can be given to
+// db.Where(). Single values become a string and repeated keys a []string, so gorm turns them into IN-queries.
+func FromValues(values url.Values, opts ...ValuesOption) map[string]any {
+	config := newValuesConfig(opts)
+
+	keys := sortedKeys(values)
+
+	columns := map[string][]string{}
+
+	for _, key := range keys {
+		if config.reservedKeys[key] || !config.allowed(key) || len(values[key]) == 0 {
+			continue
+		}
+
+		column := config.column(key)
+		columns[column] = append(columns[column], values[key]...)
+	}
+
+	result := make(map[string]any, len(columns))
+
+	for column, columnValues := range columns {
+		if len(columnValues) == 1 {
+			result[column] = columnValues[0]
+			continue
+		}
+
+		result[column] = columnValues
+	}
+
+	return result
+}
+
+// newValuesConfig creates a config with the defaults and applies the options to it
+func newValuesConfig(opts []ValuesOption) *valuesConfig {
+	config := &valuesConfig{reservedKeys: toSet(defaultReservedKeys)}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// allowed returns true if the key may be used to filter
+func (c *valuesConfig) allowed(key string) bool {
+	return c.allowedKeys == nil || c.allowedKeys[key]
+}
+
+// column returns the column that the key filters on
+func (c *valuesConfig) column(key string) string {
+	if name, ok := c.columnNames[key]; ok {
+		return name
+	}
+
+	return key
+}
+
+// toSet turns a list of strings into a map for quick lookups
+func toSet(keys []string) map[string]bool {
+	result := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		result[key] = true
+	}
+
+	return result
+}
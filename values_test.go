@@ -0,0 +1,115 @@
+package gormqonvert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromValues_ReturnsExpectedFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		target   string
+		options  []ValuesOption
+		expected map[string]any
+	}{
+		"nothing": {
+			target:   "/",
+			expected: map[string]any{},
+		},
+		"single values": {
+			target:   "/?name=jessica&age=%3E%3D30",
+			expected: map[string]any{"name": "jessica", "age": ">=30"},
+		},
+		"repeated values": {
+			target:   "/?name=jessica&name=amy",
+			expected: map[string]any{"name": []string{"jessica", "amy"}},
+		},
+		"default reserved keys": {
+			target:   "/?name=jessica&page=2&sort=name",
+			expected: map[string]any{"name": "jessica"},
+		},
+		"custom reserved keys": {
+			target:   "/?name=jessica&page=2&limit=10",
+			options:  []ValuesOption{ReservedKeys("limit")},
+			expected: map[string]any{"name": "jessica", "page": "2"},
+		},
+		"allowed keys": {
+			target:   "/?name=jessica&password=secret",
+			options:  []ValuesOption{AllowedKeys("name")},
+			expected: map[string]any{"name": "jessica"},
+		},
+		"column names": {
+			target:   "/?customerName=jessica&age=30",
+			options:  []ValuesOption{ColumnNames(map[string]string{"customerName": "customer_name"})},
+			expected: map[string]any{"customer_name": "jessica", "age": "30"},
+		},
+		"column names merge values": {
+			target:   "/?customerName=jessica&customer_name=amy",
+			options:  []ValuesOption{ColumnNames(map[string]string{"customerName": "customer_name"})},
+			expected: map[string]any{"customer_name": []string{"jessica", "amy"}},
+		},
+		"allowed keys before column names": {
+			target: "/?customerName=jessica&customer_name=amy",
+			options: []ValuesOption{
+				AllowedKeys("customerName"),
+				ColumnNames(map[string]string{"customerName": "customer_name"}),
+			},
+			expected: map[string]any{"customer_name": "jessica"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			request := httptest.NewRequest(http.MethodGet, testData.target, nil)
+
+			// Act
+			result := FromValues(request.URL.Query(), testData.options...)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestFromValues_FiltersInHandler(t *testing.T) {
+	t.Parallel()
+
+	type ObjectD struct {
+		Name string
+		Age  int
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectD{})
+	_ = db.Use(New(CharacterConfig{GreaterThanPrefix: ">", LikePrefix: "~"}))
+
+	existing := []ObjectD{{Name: "jessica", Age: 29}, {Name: "amy", Age: 30}, {Name: "boris", Age: 31}}
+	if err := db.CreateInBatches(existing, 10).Error; err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var actual []ObjectD
+
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		filter := FromValues(request.URL.Query(), AllowedKeys("name", "age"))
+		assert.NoError(t, db.Where(filter).Find(&actual).Error)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/?age=%3E29&name=~%25s%25&page=1", nil)
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	// Assert
+	assert.Equal(t, []ObjectD{{Name: "boris", Age: 31}}, actual)
+}
@@ -0,0 +1,30 @@
+package gormqonvert
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VirtualField is a filter key that is backed by an SQL expression instead of a column, like
+// "first_name || ' ' || last_name". Placeholders in the SQL are filled using Vars, which can be used to add
+// clause.Column{Table: clause.CurrentTable, Name: "first_name"} to prevent ambiguous columns in joins.
+type VirtualField struct {
+	// SQL is used for dialects that are not in Dialects
+	SQL string
+
+	// Dialects contains the SQL per dialect, by the name of the gorm dialector like "postgres" or "sqlite"
+	Dialects map[string]string
+
+	// Vars are the values of the placeholders in the SQL
+	Vars []any
+}
+
+// expression returns the SQL of the field for the dialect of the query between parentheses
+func (v VirtualField) expression(db *gorm.DB) clause.Expression {
+	sql, ok := v.Dialects[db.Dialector.Name()]
+	if !ok {
+		sql = v.SQL
+	}
+
+	return clause.Expr{SQL: "(" + sql + ")", Vars: v.Vars}
+}
@@ -0,0 +1,104 @@
+package gormqonvert
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestGormQonvert_VirtualFields_ComparesExpression(t *testing.T) {
+	t.Parallel()
+
+	type ObjectG struct {
+		FirstName string
+		LastName  string
+	}
+
+	fullName := VirtualField{
+		SQL:      "CONCAT(first_name, ' ', last_name)",
+		Dialects: map[string]string{"sqlite": "first_name || ' ' || last_name"},
+	}
+
+	tests := map[string]struct {
+		field    VirtualField
+		filter   map[string]any
+		expected string
+	}{
+		"plain value": {
+			field:    fullName,
+			filter:   map[string]any{"full_name": "jessica smith"},
+			expected: "SELECT * FROM `object_gs` WHERE (first_name || ' ' || last_name) = \"jessica smith\"",
+		},
+		"prefixed value": {
+			field:    fullName,
+			filter:   map[string]any{"full_name": "~jes%"},
+			expected: "SELECT * FROM `object_gs` WHERE (first_name || ' ' || last_name) LIKE \"jes%\"",
+		},
+		"multiple values": {
+			field:    fullName,
+			filter:   map[string]any{"full_name": []string{"jessica smith", "amy pond"}},
+			expected: "SELECT * FROM `object_gs` WHERE ((first_name || ' ' || last_name) = \"jessica smith\" OR (first_name || ' ' || last_name) = \"amy pond\")",
+		},
+		"default sql": {
+			field:    VirtualField{SQL: "CONCAT(first_name, ' ', last_name)"},
+			filter:   map[string]any{"full_name": "!=jessica smith"},
+			expected: "SELECT * FROM `object_gs` WHERE (CONCAT(first_name, ' ', last_name)) != \"jessica smith\"",
+		},
+		"vars": {
+			field: VirtualField{
+				SQL:  "? || ?",
+				Vars: []any{clause.Column{Table: clause.CurrentTable, Name: "first_name"}, "!"},
+			},
+			filter:   map[string]any{"full_name": "jessica!"},
+			expected: "SELECT * FROM `object_gs` WHERE (`object_gs`.`first_name` || \"!\") = \"jessica!\"",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			config := CharacterConfig{LikePrefix: "~", NotEqualToPrefix: "!="}
+
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.Use(New(config, WithVirtualField("full_name", testData.field)))
+
+			// Act
+			result := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(testData.filter).Find(&[]ObjectG{})
+			})
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormQonvert_VirtualFields_FiltersQuery(t *testing.T) {
+	t.Parallel()
+
+	type ObjectG struct {
+		FirstName string
+		LastName  string
+	}
+
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectG{})
+	_ = db.Use(New(CharacterConfig{LikePrefix: "~"}, WithVirtualField("full_name", VirtualField{SQL: "first_name || ' ' || last_name"})))
+
+	_ = db.Create([]ObjectG{{FirstName: "jessica", LastName: "smith"}, {FirstName: "amy", LastName: "pond"}}).Error
+
+	var actual []ObjectG
+
+	// Act
+	err := db.Where(map[string]any{"full_name": "~% pond"}).Find(&actual).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectG{{FirstName: "amy", LastName: "pond"}}, actual)
+}